@@ -0,0 +1,234 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package aead defines filters to encrypt/decrypt a stream of data using
+// AES-GCM in a chunked, STREAM style construction.  Arbitrarily large streams
+// are processed with constant memory, and truncation, reordering and
+// tampering of the chunks are all detected.  These filters can be connected
+// to other filters via io.Pipes.
+//
+// Encrypt and Decrypt use a caller supplied key.  EncryptWithPassphrase and
+// DecryptWithPassphrase derive the key from a passphrase using PBKDF2.
+//
+// The encrypted stream (version 1) has the form:
+//
+//	magic "AGCM" (4 bytes)
+//	version (1 byte, 0x01)
+//	nonce prefix (7 random bytes)
+//	chunk...
+//
+// Each chunk is the AES-GCM encryption of up to ChunkSize bytes of
+// plaintext, followed by its 16 byte tag.  All chunks except the last hold
+// exactly ChunkSize bytes of plaintext; the last chunk may be shorter (or
+// empty).  The 12 byte nonce of each chunk is:
+//
+//	nonce prefix (7 bytes) || chunk counter (4 bytes, big endian) || last (1 byte)
+//
+// where last is 0x01 for the final chunk and 0x00 otherwise.  The 12 byte
+// header is passed as the additional data for every chunk.
+package aead
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	// Version is the version of the encrypted stream format.
+	Version = 1
+	// ChunkSize is the number of bytes of plaintext in each chunk.
+	ChunkSize = 64 * 1024
+	// HeaderSize is the size of the header at the start of the stream.
+	HeaderSize = 12
+
+	magic       = "AGCM"
+	prefixSize  = 7
+	tagOverhead = 16
+)
+
+var (
+	// ErrAuthentication is returned when a chunk fails to decrypt because
+	// the key is wrong or the data has been modified or reordered.
+	ErrAuthentication = errors.New("aead: message authentication failed")
+	// ErrTruncated is returned when the stream ends before the last chunk.
+	ErrTruncated = errors.New("aead: encrypted stream is truncated")
+	// ErrFormat is returned when the stream does not start with a valid header.
+	ErrFormat = errors.New("aead: not an encrypted stream")
+)
+
+// newGCM returns an AES-GCM cipher using key, which must be 16, 24 or 32
+// bytes long.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("aead: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// setNonce fills in the counter and last flag of nonce.
+func setNonce(nonce []byte, counter uint64, last bool) error {
+	if counter > math.MaxUint32 {
+		return fmt.Errorf("aead: too many chunks in the stream")
+	}
+	nonce[prefixSize] = byte(counter >> 24)
+	nonce[prefixSize+1] = byte(counter >> 16)
+	nonce[prefixSize+2] = byte(counter >> 8)
+	nonce[prefixSize+3] = byte(counter)
+	nonce[prefixSize+4] = 0
+	if last {
+		nonce[prefixSize+4] = 1
+	}
+	return nil
+}
+
+// readChunk reads up to len(buf) bytes from bRdr and reports if it was the
+// last chunk in the stream.
+func readChunk(bRdr *bufio.Reader, buf []byte) (int, bool, error) {
+	n, err := io.ReadFull(bRdr, buf)
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return n, true, nil
+	case err != nil:
+		return n, false, err
+	}
+	if _, err = bRdr.Peek(1); errors.Is(err, io.EOF) {
+		return n, true, nil
+	} else if err != nil {
+		return n, false, err
+	}
+	return n, false, nil
+}
+
+// Encrypt reads data from r and encrypts it with AES-GCM using key, which
+// must be 16, 24 or 32 bytes long.  The encrypted data can be read using the
+// returned PipeReader.
+func Encrypt(r io.Reader, key []byte) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		gcm, err := newGCM(key)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		hdr := make([]byte, HeaderSize)
+		copy(hdr, magic)
+		hdr[len(magic)] = Version
+		if _, err = rand.Read(hdr[len(magic)+1:]); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error generating a nonce prefix: %w", err))
+			return
+		}
+		if _, err = rWrtr.Write(hdr); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing the header to an io.PipeWriter: %w", err))
+			return
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		copy(nonce, hdr[len(magic)+1:])
+		bRdr := bufio.NewReaderSize(r, ChunkSize)
+		buf := make([]byte, ChunkSize, ChunkSize+tagOverhead)
+		for counter := uint64(0); ; counter++ {
+			n, last, err := readChunk(bRdr, buf)
+			if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			if err = setNonce(nonce, counter, last); err != nil {
+				rWrtr.CloseWithError(err)
+				return
+			}
+			if _, err = rWrtr.Write(gcm.Seal(buf[:0], nonce, buf[:n], hdr)); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing a chunk to an io.PipeWriter: %w", err))
+				return
+			}
+			if last {
+				return
+			}
+			buf = buf[:ChunkSize]
+		}
+	}()
+
+	return rRdr
+}
+
+// Decrypt reads data encrypted by Encrypt from r and decrypts it using key.
+// The decrypted data can be read using the returned PipeReader.  Only chunks
+// that have been authenticated are released; if a chunk fails to
+// authenticate, or the stream is truncated, the read returns an error.
+func Decrypt(r io.Reader, key []byte) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		gcm, err := newGCM(key)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		hdr := make([]byte, HeaderSize)
+		if _, err = io.ReadFull(r, hdr); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error reading the header: %w", ErrFormat))
+			return
+		}
+		if !bytes.Equal(hdr[:len(magic)], []byte(magic)) {
+			rWrtr.CloseWithError(ErrFormat)
+			return
+		}
+		if hdr[len(magic)] != Version {
+			rWrtr.CloseWithError(fmt.Errorf("aead: unsupported version %d", hdr[len(magic)]))
+			return
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		copy(nonce, hdr[len(magic)+1:])
+		bRdr := bufio.NewReaderSize(r, ChunkSize+tagOverhead)
+		buf := make([]byte, ChunkSize+tagOverhead)
+		plainBuf := make([]byte, ChunkSize)
+		for counter := uint64(0); ; counter++ {
+			n, last, err := readChunk(bRdr, buf)
+			if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			if n < tagOverhead {
+				rWrtr.CloseWithError(fmt.Errorf("error reading chunk %d: %w", counter, ErrTruncated))
+				return
+			}
+			if err = setNonce(nonce, counter, last); err != nil {
+				rWrtr.CloseWithError(err)
+				return
+			}
+			plain, err := gcm.Open(plainBuf[:0], nonce, buf[:n], hdr)
+			if err != nil {
+				if last {
+					// A truncated stream ends with a chunk that is not marked as last.
+					if setNonce(nonce, counter, false) == nil {
+						if _, err = gcm.Open(nil, nonce, buf[:n], hdr); err == nil {
+							rWrtr.CloseWithError(fmt.Errorf("chunk %d is not the last chunk: %w", counter, ErrTruncated))
+							return
+						}
+					}
+				}
+				rWrtr.CloseWithError(fmt.Errorf("chunk %d failed to decrypt: %w", counter, ErrAuthentication))
+				return
+			}
+			if _, err = rWrtr.Write(plain); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+			if last {
+				return
+			}
+		}
+	}()
+
+	return rRdr
+}
@@ -0,0 +1,82 @@
+package aead
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+var testKey = bytes.Repeat([]byte{0x5a}, 32)
+
+func TestRoundTrip(t *testing.T) {
+	type args struct {
+		data []byte
+		key  []byte
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Empty",
+			args: args{data: []byte{}, key: testKey},
+		},
+		{
+			name: "TestOne",
+			args: args{data: []byte("This is only a test"), key: testKey[:16]},
+		},
+		{
+			name: "ExactChunk",
+			args: args{data: bytes.Repeat([]byte{'a'}, ChunkSize), key: testKey[:24]},
+		},
+		{
+			name: "ManyChunks",
+			args: args{data: bytes.Repeat([]byte("0123456789"), ChunkSize/3), key: testKey},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(Decrypt(Encrypt(bytes.NewReader(tt.args.data), tt.args.key), tt.args.key))
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.args.data) {
+				t.Errorf("Decrypt() returned %d bytes, want %d", len(got), len(tt.args.data))
+			}
+		})
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), ChunkSize/4)
+	stream, _ := io.ReadAll(Encrypt(bytes.NewReader(data), testKey))
+	chunk := ChunkSize + tagOverhead
+	hdr, c0, c1, c2 := stream[:HeaderSize], stream[HeaderSize:HeaderSize+chunk],
+		stream[HeaderSize+chunk:HeaderSize+2*chunk], stream[HeaderSize+2*chunk:]
+	tampered := bytes.Clone(stream)
+	tampered[HeaderSize+10] ^= 0x01
+	badMagic := bytes.Clone(stream)
+	badMagic[0] = 'X'
+	tests := []struct {
+		name string
+		in   []byte
+		key  []byte
+		want error
+	}{
+		{name: "WrongKey", in: stream, key: bytes.Repeat([]byte{0xa5}, 32), want: ErrAuthentication},
+		{name: "Tampered", in: tampered, key: testKey, want: ErrAuthentication},
+		{name: "TruncatedAtChunk", in: bytes.Join([][]byte{hdr, c0, c1}, nil), key: testKey, want: ErrTruncated},
+		{name: "TruncatedInChunk", in: stream[:len(stream)-1], key: testKey, want: ErrAuthentication},
+		{name: "HeaderOnly", in: hdr, key: testKey, want: ErrTruncated},
+		{name: "Reordered", in: bytes.Join([][]byte{hdr, c1, c0, c2}, nil), key: testKey, want: ErrAuthentication},
+		{name: "BadMagic", in: badMagic, key: testKey, want: ErrFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := io.ReadAll(Decrypt(bytes.NewReader(tt.in), tt.key)); !errors.Is(err, tt.want) {
+				t.Errorf("Decrypt() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package aead
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The passphrase encrypted stream (version 1) has the form:
+//
+//	magic "APBE" (4 bytes)
+//	version (1 byte, 0x01)
+//	KDF identifier (1 byte, KDFPBKDF2SHA256)
+//	iteration count (4 bytes, big endian)
+//	salt length (1 byte)
+//	salt
+//	verifier (32 bytes)
+//	encrypted stream (see Encrypt)
+//
+// PBKDF2 derives 64 bytes from the passphrase: the first 32 bytes are the
+// AES-256 key used to encrypt the stream and the last 32 bytes are the key
+// used to compute the verifier, an HMAC-SHA256 over the preceding header.
+// The verifier allows a wrong passphrase to be detected before any data is
+// decrypted.
+
+const (
+	// KDFPBKDF2SHA256 identifies PBKDF2 with HMAC-SHA256 as the KDF.
+	KDFPBKDF2SHA256 = 1
+	// DefaultIterations is the PBKDF2 iteration count used by
+	// EncryptWithPassphrase.
+	DefaultIterations = 600000
+	// SaltSize is the size of the random salt used by EncryptWithPassphrase.
+	SaltSize = 16
+
+	pbeMagic     = "APBE"
+	verifierSize = sha256.Size
+	// maxIterations bounds the iteration count read from a header, so that
+	// a hostile stream cannot force a long key derivation before the
+	// verifier rejects it.
+	maxIterations = 10 * DefaultIterations
+)
+
+// iterations is the PBKDF2 iteration count used by EncryptWithPassphrase.
+// The tests lower it to keep them fast.
+var iterations = DefaultIterations
+
+// ErrWrongPassphrase is returned when the passphrase does not match the one
+// used to encrypt the stream.
+var ErrWrongPassphrase = errors.New("aead: wrong passphrase")
+
+// deriveKeys derives the encryption key and the verifier key from passphrase.
+func deriveKeys(passphrase string, salt []byte, iterations int) (encKey, macKey []byte, err error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("aead: error deriving a key from the passphrase: %w", err)
+	}
+	return key[:32], key[32:], nil
+}
+
+// computeVerifier calculates the verifier for the header hdr.
+func computeVerifier(macKey, hdr []byte) []byte {
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(hdr)
+	return mac.Sum(nil)
+}
+
+// EncryptWithPassphrase reads data from r and encrypts it using a key
+// derived from passphrase with PBKDF2.  The salt, iteration count and KDF
+// identifier are stored in a header in front of the encrypted stream.  The
+// encrypted data can be read using the returned PipeReader.
+func EncryptWithPassphrase(r io.Reader, passphrase string) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		salt := make([]byte, SaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error generating a salt: %w", err))
+			return
+		}
+		encKey, macKey, err := deriveKeys(passphrase, salt, iterations)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		var hdr bytes.Buffer
+		hdr.WriteString(pbeMagic)
+		hdr.WriteByte(Version)
+		hdr.WriteByte(KDFPBKDF2SHA256)
+		binary.Write(&hdr, binary.BigEndian, uint32(iterations))
+		hdr.WriteByte(byte(len(salt)))
+		hdr.Write(salt)
+		hdr.Write(computeVerifier(macKey, hdr.Bytes()))
+		if _, err = rWrtr.Write(hdr.Bytes()); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing the header to an io.PipeWriter: %w", err))
+			return
+		}
+		if _, err = io.Copy(rWrtr, Encrypt(r, encKey)); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an aead.Encrypt filter to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// DecryptWithPassphrase reads data encrypted by EncryptWithPassphrase from r
+// and decrypts it using a key derived from passphrase.  The decrypted data
+// can be read using the returned PipeReader.  If the passphrase is wrong, the
+// read returns ErrWrongPassphrase without any data being released.
+func DecryptWithPassphrase(r io.Reader, passphrase string) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		var hdr [len(pbeMagic) + 7]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error reading the passphrase header: %w", ErrFormat))
+			return
+		}
+		if string(hdr[:len(pbeMagic)]) != pbeMagic {
+			rWrtr.CloseWithError(ErrFormat)
+			return
+		}
+		if v := hdr[len(pbeMagic)]; v != Version {
+			rWrtr.CloseWithError(fmt.Errorf("aead: unsupported passphrase header version %d", v))
+			return
+		}
+		if kdf := hdr[len(pbeMagic)+1]; kdf != KDFPBKDF2SHA256 {
+			rWrtr.CloseWithError(fmt.Errorf("aead: unsupported KDF identifier %d", kdf))
+			return
+		}
+		count := binary.BigEndian.Uint32(hdr[len(pbeMagic)+2:])
+		if count == 0 || count > maxIterations {
+			rWrtr.CloseWithError(fmt.Errorf("aead: invalid iteration count %d", count))
+			return
+		}
+		rest := make([]byte, int(hdr[len(hdr)-1])+verifierSize)
+		if _, err := io.ReadFull(r, rest); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error reading the passphrase header: %w", ErrFormat))
+			return
+		}
+		salt, verifier := rest[:len(rest)-verifierSize], rest[len(rest)-verifierSize:]
+		encKey, macKey, err := deriveKeys(passphrase, salt, int(count))
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		if !hmac.Equal(verifier, computeVerifier(macKey, append(hdr[:], salt...))) {
+			rWrtr.CloseWithError(ErrWrongPassphrase)
+			return
+		}
+		if _, err = io.Copy(rWrtr, Decrypt(r, encKey)); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an aead.Decrypt filter to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
@@ -0,0 +1,67 @@
+package aead
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func init() {
+	// A low iteration count keeps the tests fast.
+	iterations = 1000
+}
+
+func TestPassphraseRoundTrip(t *testing.T) {
+	want := strings.Repeat("This is only a test.  ", 5000)
+	enc, err := io.ReadAll(EncryptWithPassphrase(strings.NewReader(want), "correct horse battery staple"))
+	if err != nil {
+		t.Fatalf("EncryptWithPassphrase() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		passphrase string
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "RightPassphrase",
+			passphrase: "correct horse battery staple",
+			want:       want,
+		},
+		{
+			name:       "WrongPassphrase",
+			passphrase: "incorrect horse battery staple",
+			wantErr:    ErrWrongPassphrase,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(DecryptWithPassphrase(bytes.NewReader(enc), tt.passphrase))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecryptWithPassphrase() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("DecryptWithPassphrase() returned %d bytes, want %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestDecryptWithPassphraseNotEncrypted(t *testing.T) {
+	_, err := io.ReadAll(DecryptWithPassphrase(strings.NewReader("This is only a test"), "passphrase"))
+	if !errors.Is(err, ErrFormat) {
+		t.Errorf("DecryptWithPassphrase() error = %v, want %v", err, ErrFormat)
+	}
+}
+
+func TestDecryptWithPassphraseTooManyIterations(t *testing.T) {
+	hdr := []byte(pbeMagic + "\x01\x01\x00\x00\x00\x00\x00")
+	binary.BigEndian.PutUint32(hdr[len(pbeMagic)+2:], maxIterations+1)
+	_, err := io.ReadAll(DecryptWithPassphrase(bytes.NewReader(hdr), "passphrase"))
+	if err == nil || !strings.Contains(err.Error(), "invalid iteration count") {
+		t.Errorf("DecryptWithPassphrase() error = %v, want an invalid iteration count", err)
+	}
+}
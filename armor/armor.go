@@ -0,0 +1,378 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package armor defines filters to encode/decode data to/from OpenPGP ASCII
+// armor (RFC 4880, section 6).  Armor is similar to PEM, but has a blank
+// line after the armor headers and a CRC-24 checksum line after the base64
+// data.  Dash-escaped cleartext signed messages are supported as well.
+// These filters can be connected to other filters via io.Pipes.
+//
+// The encoded form is:
+//
+//	-----BEGIN Type-----
+//	Headers
+//
+//	base64-encoded Bytes
+//	=CRC-24 checksum
+//	-----END Type-----
+package armor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bgallie/filters/base64"
+	"github.com/bgallie/filters/lines"
+)
+
+// The armor types defined by RFC 4880.
+const (
+	MessageType    = "PGP MESSAGE"
+	SignatureType  = "PGP SIGNATURE"
+	PublicKeyType  = "PGP PUBLIC KEY BLOCK"
+	PrivateKeyType = "PGP PRIVATE KEY BLOCK"
+	// SignedMessageType is the type that starts a cleartext signed message.
+	SignedMessageType = "PGP SIGNED MESSAGE"
+)
+
+// LineWidth is the number of base64 characters per line written by ToArmor.
+const LineWidth = 64
+
+// ErrChecksum is returned when the CRC-24 checksum does not match the data.
+var ErrChecksum = errors.New("armor: CRC-24 checksum mismatch")
+
+// A Header is a single "Key: Value" armor header.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// A Block represents the type and the armor headers of armored data.
+type Block struct {
+	Type    string   // The type, taken from the armor header line (i.e. "PGP MESSAGE").
+	Headers []Header // Optional armor headers, in order.
+}
+
+// Get returns the value of the first header with the given key.
+func (b Block) Get(key string) string {
+	for _, h := range b.Headers {
+		if h.Key == key {
+			return h.Value
+		}
+	}
+	return ""
+}
+
+// crc24 is an io.Writer that computes the CRC-24 checksum of the data
+// written to it.
+type crc24 uint32
+
+const (
+	crc24Init = 0xb704ce
+	crc24Poly = 0x1864cfb
+)
+
+func newCRC24() *crc24 {
+	c := crc24(crc24Init)
+	return &c
+}
+
+func (c *crc24) Write(p []byte) (int, error) {
+	crc := uint32(*c)
+	for _, b := range p {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= crc24Poly
+			}
+		}
+	}
+	*c = crc24(crc & 0xffffff)
+	return len(p), nil
+}
+
+// encode returns the checksum line, without its line ending.
+func (c *crc24) encode() string {
+	sum := []byte{byte(*c >> 16), byte(*c >> 8), byte(*c)}
+	enc, _ := io.ReadAll(base64.ToBase64(strings.NewReader(string(sum))))
+	return "=" + string(enc)
+}
+
+// writeArmor writes data read from r to w as armor using blk.
+func writeArmor(w io.Writer, r io.Reader, blk Block) error {
+	if _, err := fmt.Fprintf(w, "-----BEGIN %s-----\n", blk.Type); err != nil {
+		return fmt.Errorf("failure printing armor BEGIN line to an io.Writer: %w", err)
+	}
+	for _, h := range blk.Headers {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", h.Key, h.Value); err != nil {
+			return fmt.Errorf("failure printing armor Header line to an io.Writer: %w", err)
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return fmt.Errorf("failure printing armor blank line to an io.Writer: %w", err)
+	}
+	crc := newCRC24()
+	body := lines.SplitToLines(base64.ToBase64(io.TeeReader(r, crc)), lines.WithLineSize(LineWidth))
+	if _, err := io.Copy(w, body); err != nil {
+		return fmt.Errorf("failure copying (io.Copy) base64 encoded data to an io.Writer: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n-----END %s-----\n", crc.encode(), blk.Type); err != nil {
+		return fmt.Errorf("failure printing armor END line to an io.Writer: %w", err)
+	}
+	return nil
+}
+
+// ToArmor reads data from r and encodes it as OpenPGP ASCII armor.  The
+// 'blk' parameter provides the "Type" and "Headers" in the encoded form.
+// The armored data can be read using the returned PipeReader.
+func ToArmor(r io.Reader, blk Block) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		if err := writeArmor(rWrtr, r, blk); err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr
+}
+
+// readLine returns the next line from bRdr without its line ending.
+func readLine(bRdr *bufio.Reader) (string, error) {
+	line, err := bRdr.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// parseDelimiter returns the type from a "-----BEGIN Type-----" or
+// "-----END Type-----" line, where kind is "BEGIN" or "END".
+func parseDelimiter(line, kind string) (string, bool) {
+	line = strings.TrimRight(line, " \t")
+	prefix := "-----" + kind + " "
+	if !strings.HasPrefix(line, prefix) || !strings.HasSuffix(line, "-----") || len(line) < len(prefix)+5 {
+		return "", false
+	}
+	return line[len(prefix) : len(line)-5], true
+}
+
+// readHeaders reads the armor headers into blk and returns the first line
+// of the body.
+func readHeaders(bRdr *bufio.Reader, blk *Block) (string, error) {
+	for {
+		line, err := readLine(bRdr)
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(line) == "" {
+			// The blank line that ends the headers.
+			return readLine(bRdr)
+		}
+		k, v, ok := strings.Cut(line, ": ")
+		if !ok || strings.HasPrefix(line, "-----") {
+			// There is no blank line after the headers; be lenient.
+			return line, nil
+		}
+		blk.Headers = append(blk.Headers, Header{Key: k, Value: v})
+	}
+}
+
+// readArmor reads the body of armored data, starting with line, after its
+// headers have been read.  The decoded data is written to w.
+func readArmor(bRdr *bufio.Reader, line string, blk Block, w io.Writer) error {
+	b64R, b64W := io.Pipe()
+	checksum := make(chan string, 1)
+
+	go func() {
+		defer b64W.Close()
+		sum := ""
+		defer func() { checksum <- sum }()
+		var err error
+		for {
+			line = strings.TrimSpace(line)
+			if typ, ok := parseDelimiter(line, "END"); ok {
+				if typ != blk.Type {
+					b64W.CloseWithError(fmt.Errorf("incorrectly formed armor: BEGIN/END type mismatch"))
+				}
+				return
+			}
+			if strings.HasPrefix(line, "=") && len(line) == 5 {
+				sum = line
+			} else if sum != "" {
+				b64W.CloseWithError(fmt.Errorf("incorrectly formed armor: data after the checksum"))
+				return
+			} else if _, err = io.WriteString(b64W, line); err != nil {
+				b64W.CloseWithError(fmt.Errorf("failed to write to a base64.Decoder: %w", err))
+				return
+			}
+			if line, err = readLine(bRdr); err != nil {
+				b64W.CloseWithError(fmt.Errorf("incorrectly formed armor: missing END line: %w", err))
+				return
+			}
+		}
+	}()
+
+	crc := newCRC24()
+	if _, err := io.Copy(io.MultiWriter(w, crc), base64.FromBase64(b64R)); err != nil {
+		b64R.CloseWithError(err)
+		return fmt.Errorf("error copying (io.Copy) from a base64.Decoder to an io.Writer: %w", err)
+	}
+	if sum := <-checksum; sum != "" && sum != crc.encode() {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// FromArmor reads OpenPGP ASCII armored data from r and decodes it.  The
+// armor information is returned in the armor.Block structure and the decoded
+// data can be read using the returned PipeReader.  If the armor has a
+// checksum that does not match the data, the final read returns ErrChecksum.
+func FromArmor(r io.Reader) (*io.PipeReader, Block) {
+	rRdr, rWrtr := io.Pipe()
+	var blk Block
+	bRdr := bufio.NewReader(r)
+	line, err := readLine(bRdr)
+	if err != nil {
+		rWrtr.CloseWithError(fmt.Errorf("missing armor: %w", err))
+		return rRdr, blk
+	}
+	typ, ok := parseDelimiter(line, "BEGIN")
+	if !ok {
+		rWrtr.CloseWithError(fmt.Errorf("incorrectly formed armor: no BEGIN line"))
+		return rRdr, blk
+	}
+	blk.Type = typ
+	line, err = readHeaders(bRdr, &blk)
+	if err != nil {
+		rWrtr.CloseWithError(fmt.Errorf("incomplete/malformed armor: %w", err))
+		return rRdr, blk
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		if err := readArmor(bRdr, line, blk, rWrtr); err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr, blk
+}
+
+// ToClearsigned reads text from r and writes it as a cleartext signed
+// message (RFC 4880, section 7), followed by the signature read from sig,
+// armored as a PGP SIGNATURE.  Lines of text that start with '-' are dash
+// escaped.  hash names the hash algorithm used for the signature (i.e.
+// "SHA256").  The signature is read only after all of the text has been
+// written, so it may be computed as the text passes through.  The signed
+// message can be read using the returned PipeReader.
+func ToClearsigned(r io.Reader, hash string, sig io.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		_, err := fmt.Fprintf(rWrtr, "-----BEGIN %s-----\nHash: %s\n\n", SignedMessageType, hash)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("failure printing armor BEGIN line to an io.PipeWriter: %w", err))
+			return
+		}
+		bRdr := bufio.NewReader(r)
+		for {
+			line, err := bRdr.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				rWrtr.CloseWithError(fmt.Errorf("error reading text from an io.Reader: %w", err))
+				return
+			}
+			if strings.HasPrefix(line, "-") {
+				line = "- " + line
+			}
+			if _, werr := io.WriteString(rWrtr, line); werr != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing text to an io.PipeWriter: %w", werr))
+				return
+			}
+			if err != nil {
+				break
+			}
+		}
+		// The line ending before the signature is not part of the text.
+		if _, err = io.WriteString(rWrtr, "\n"); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing text to an io.PipeWriter: %w", err))
+			return
+		}
+		if err = writeArmor(rWrtr, sig, Block{Type: SignatureType}); err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr
+}
+
+// FromClearsigned reads a cleartext signed message from r.  The "Hash"
+// headers are returned in the armor.Block structure and the text, with the
+// dash escaping removed, can be read using the returned PipeReader.  After
+// all of the text has been read, the decoded signature is written to sig
+// before the PipeReader returns io.EOF.
+func FromClearsigned(r io.Reader, sig io.Writer) (*io.PipeReader, Block) {
+	rRdr, rWrtr := io.Pipe()
+	var blk Block
+	bRdr := bufio.NewReader(r)
+	line, err := readLine(bRdr)
+	if err != nil {
+		rWrtr.CloseWithError(fmt.Errorf("missing signed message: %w", err))
+		return rRdr, blk
+	}
+	if typ, ok := parseDelimiter(line, "BEGIN"); !ok || typ != SignedMessageType {
+		rWrtr.CloseWithError(fmt.Errorf("incorrectly formed signed message: no BEGIN %s line", SignedMessageType))
+		return rRdr, blk
+	}
+	blk.Type = SignedMessageType
+	line, err = readHeaders(bRdr, &blk)
+	if err != nil {
+		rWrtr.CloseWithError(fmt.Errorf("incomplete/malformed signed message: %w", err))
+		return rRdr, blk
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		for first := true; ; first = false {
+			if typ, ok := parseDelimiter(line, "BEGIN"); ok && typ == SignatureType {
+				break
+			}
+			if strings.HasPrefix(line, "- ") {
+				line = line[2:]
+			}
+			if !first {
+				line = "\n" + line
+			}
+			if _, err := io.WriteString(rWrtr, line); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing text to an io.PipeWriter: %w", err))
+				return
+			}
+			var err error
+			if line, err = readLine(bRdr); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("incorrectly formed signed message: missing signature: %w", err))
+				return
+			}
+		}
+		sigBlk := Block{Type: SignatureType}
+		line, err := readHeaders(bRdr, &sigBlk)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("incomplete/malformed signature: %w", err))
+			return
+		}
+		if err = readArmor(bRdr, line, sigBlk, sig); err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr, blk
+}
@@ -0,0 +1,121 @@
+package armor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testArmor = "-----BEGIN PGP ARMORED FILE-----\n" +
+	"Comment: Use \"gpg --dearmor\" for unpacking\n" +
+	"\n" +
+	"VGhpcyBpcyBvbmx5IGEgdGVzdCBvZiB0aGUgVG9Bcm1vciBmaWx0ZXIu\n" +
+	"=8C8b\n" +
+	"-----END PGP ARMORED FILE-----\n"
+
+func TestToArmor(t *testing.T) {
+	type args struct {
+		r   io.Reader
+		blk Block
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("This is only a test of the ToArmor filter."),
+				blk: Block{Type: "PGP ARMORED FILE", Headers: []Header{{"Comment", "Use \"gpg --dearmor\" for unpacking"}}}},
+			want: testArmor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToArmor(tt.args.r, tt.args.blk)); string(got) != tt.want {
+				t.Errorf("ToArmor() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromArmor(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		want1   Block
+		wantErr error
+	}{
+		{
+			name:  "TestOne",
+			args:  args{r: strings.NewReader(testArmor)},
+			want:  "This is only a test of the ToArmor filter.",
+			want1: Block{Type: "PGP ARMORED FILE", Headers: []Header{{"Comment", "Use \"gpg --dearmor\" for unpacking"}}},
+		},
+		{
+			name:  "NoChecksum",
+			args:  args{r: strings.NewReader(strings.Replace(testArmor, "=8C8b\n", "", 1))},
+			want:  "This is only a test of the ToArmor filter.",
+			want1: Block{Type: "PGP ARMORED FILE", Headers: []Header{{"Comment", "Use \"gpg --dearmor\" for unpacking"}}},
+		},
+		{
+			name:    "BadChecksum",
+			args:    args{r: strings.NewReader(strings.Replace(testArmor, "=8C8b", "=8C8c", 1))},
+			want:    "This is only a test of the ToArmor filter.",
+			want1:   Block{Type: "PGP ARMORED FILE", Headers: []Header{{"Comment", "Use \"gpg --dearmor\" for unpacking"}}},
+			wantErr: ErrChecksum,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, got1 := FromArmor(tt.args.r)
+			got, err := io.ReadAll(rdr)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromArmor() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromArmor() got = %v, want %v", string(got), tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("FromArmor() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestClearsigned(t *testing.T) {
+	text := "Hello,\n-- \nThis is only a test.\n- a list item\n"
+	sig := []byte{0x88, 0x75, 0x04, 0x01, 0x16, 0x08, 0x00, 0x1d}
+	signed, err := io.ReadAll(ToClearsigned(strings.NewReader(text), "SHA256", bytes.NewReader(sig)))
+	if err != nil {
+		t.Fatalf("ToClearsigned() error = %v", err)
+	}
+	want := "-----BEGIN PGP SIGNED MESSAGE-----\nHash: SHA256\n\n" +
+		"Hello,\n- -- \nThis is only a test.\n- - a list item\n\n" +
+		"-----BEGIN PGP SIGNATURE-----\n\niHUEARYIAB0=\n=T2A8\n-----END PGP SIGNATURE-----\n"
+	if string(signed) != want {
+		t.Errorf("ToClearsigned() = %q, want %q", signed, want)
+	}
+	var gotSig bytes.Buffer
+	rdr, blk := FromClearsigned(bytes.NewReader(signed), &gotSig)
+	got, err := io.ReadAll(rdr)
+	if err != nil {
+		t.Fatalf("FromClearsigned() error = %v", err)
+	}
+	if string(got) != text {
+		t.Errorf("FromClearsigned() = %q, want %q", got, text)
+	}
+	if blk.Get("Hash") != "SHA256" {
+		t.Errorf("FromClearsigned() Hash = %q, want %q", blk.Get("Hash"), "SHA256")
+	}
+	if !bytes.Equal(gotSig.Bytes(), sig) {
+		t.Errorf("FromClearsigned() signature = %x, want %x", gotSig.Bytes(), sig)
+	}
+}
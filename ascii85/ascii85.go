@@ -3,27 +3,159 @@
 // license that can be found in the LICENSE file.
 
 // Package ascii85 defines filters to encode/decode data using ASCII85 encoding.
-// These filters can be connected to other filters via io.Pipes.
+// Besides bare ASCII85, the Adobe variant, framed by "<~" and "~>", and the
+// btoa variant, framed by "xbtoa Begin" and "xbtoa End" lines, are
+// supported.  These filters can be connected to other filters via io.Pipes.
 package ascii85
 
 import (
+	"bufio"
 	"encoding/ascii85"
+	"errors"
 	"fmt"
 	"io"
 )
 
+// A Variant selects the framing of the ASCII85 encoded data.
+type Variant int
+
+const (
+	// Plain is bare ASCII85 without any framing.
+	Plain Variant = iota
+	// Adobe is ASCII85 framed by "<~" and "~>", as used by PostScript and
+	// PDF.
+	Adobe
+	// Btoa is the format written by btoa(1), framed by "xbtoa Begin" and
+	// "xbtoa End" lines, with the end line holding the length and
+	// checksums of the data.
+	Btoa
+)
+
+const (
+	adobeBegin = "<~"
+	adobeEnd   = "~>"
+)
+
+var (
+	// ErrFormat is returned when the framing of the encoded data is
+	// malformed.
+	ErrFormat = errors.New("ascii85: malformed framing")
+	// ErrChecksum is returned when the length or checksums in a btoa end
+	// line do not match the data.
+	ErrChecksum = errors.New("ascii85: btoa checksum mismatch")
+)
+
+// An Option configures the behaviour of ToASCII85.
+type Option func(*options)
+
+type options struct {
+	variant Variant
+}
+
+// WithVariant selects the framing written by ToASCII85.  The default is
+// Plain.
+func WithVariant(v Variant) Option {
+	return func(o *options) {
+		o.variant = v
+	}
+}
+
 // ToASCII85 reads data from r, encodes it using Ascii85.
 // The Ascii85 encoded data can be read using the returned PipeReader.
-func ToASCII85(r io.Reader) *io.PipeReader {
+func ToASCII85(r io.Reader, opts ...Option) *io.PipeReader {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.variant == Btoa {
+		return toBtoa(r)
+	}
 	rRdr, rWrtr := io.Pipe()
 	ascii85W := ascii85.NewEncoder(rWrtr)
 
 	go func() {
 		defer rWrtr.Close()
-		defer ascii85W.Close()
+		if o.variant == Adobe {
+			if _, err := io.WriteString(rWrtr, adobeBegin); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+		}
 		_, err := io.Copy(ascii85W, r)
 		if err != nil {
 			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an io.Reader to an ascii85.Encoder: %w", err))
+			return
+		}
+		if err = ascii85W.Close(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error closing an ascii85.Encoder: %w", err))
+			return
+		}
+		if o.variant == Adobe {
+			if _, err = io.WriteString(rWrtr, adobeEnd); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+			}
+		}
+	}()
+
+	return rRdr
+}
+
+// detect skips leading white space in br and reports the variant that the
+// data starts with.
+func detect(br *bufio.Reader) Variant {
+	for {
+		b, err := br.Peek(1)
+		if err != nil || !isSpace(b[0]) {
+			break
+		}
+		br.Discard(1)
+	}
+	if b, _ := br.Peek(len(btoaBegin)); string(b) == btoaBegin {
+		return Btoa
+	}
+	if b, _ := br.Peek(len(adobeBegin)); string(b) == adobeBegin {
+		return Adobe
+	}
+	return Plain
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n' || b == '\v' || b == '\f'
+}
+
+// unframe discards the "<~" at the start of br and returns the data up to
+// the following "~>" through the returned PipeReader.  Anything after the
+// "~>" is not read.
+func unframe(br *bufio.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		br.Discard(len(adobeBegin))
+		for {
+			chunk, err := br.ReadSlice(adobeEnd[0])
+			data := chunk
+			if err == nil {
+				data = chunk[:len(chunk)-1]
+			}
+			if _, werr := rWrtr.Write(data); werr != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", werr))
+				return
+			}
+			switch {
+			case err == nil:
+				if b, err := br.ReadByte(); err != nil || b != adobeEnd[1] {
+					rWrtr.CloseWithError(fmt.Errorf("%w: %q not followed by %q", ErrFormat, adobeEnd[0], adobeEnd[1]))
+				}
+				return
+			case errors.Is(err, bufio.ErrBufferFull):
+			case errors.Is(err, io.EOF):
+				rWrtr.CloseWithError(fmt.Errorf("%w: missing %q", ErrFormat, adobeEnd))
+				return
+			default:
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
 		}
 	}()
 
@@ -31,14 +163,28 @@ func ToASCII85(r io.Reader) *io.PipeReader {
 }
 
 // FromASCII85 reads ascii85 encoded data from r, decodes it using the ascii85
-// decoder.  The decoded data can be read using the returned PipeReader.
+// decoder.  The variant of the data is detected from its start, and any
+// Adobe or btoa framing is removed.  The decoded data can be read using the
+// returned PipeReader.
 func FromASCII85(r io.Reader) *io.PipeReader {
 	rRdr, rWrtr := io.Pipe()
-	ascii85R := ascii85.NewDecoder(r)
 
 	go func() {
 		defer rWrtr.Close()
-		_, err := io.Copy(rWrtr, ascii85R)
+		br := bufio.NewReader(r)
+		var src io.Reader
+		switch detect(br) {
+		case Btoa:
+			if _, err := io.Copy(rWrtr, fromBtoa(br)); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from a btoa decoder to an io.PipeWriter: %w", err))
+			}
+			return
+		case Adobe:
+			src = ascii85.NewDecoder(unframe(br))
+		default:
+			src = ascii85.NewDecoder(br)
+		}
+		_, err := io.Copy(rWrtr, src)
 		if err != nil {
 			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an ascii85.Decoder to an io.PipeWriter: %w", err))
 		}
@@ -1,14 +1,21 @@
 package ascii85
 
 import (
+	"errors"
 	"io"
 	"strings"
 	"testing"
 )
 
+const (
+	testBtoa      = "xbtoa Begin\n<+oue+DGm>Df0B:+CQC7ATMqn\nxbtoa End N 19 13 E 5f S 6ea R 2e32d2e\n"
+	testBtoaSpace = "xbtoa Begin\nyz@:E^H\nxbtoa End N 11 b E 60 S 1b1 R f2ab\n"
+)
+
 func TestToASCII85(t *testing.T) {
 	type args struct {
-		r io.Reader
+		r    io.Reader
+		opts []Option
 	}
 	tests := []struct {
 		name string
@@ -20,10 +27,30 @@ func TestToASCII85(t *testing.T) {
 			args: args{r: strings.NewReader("This is only a test")},
 			want: "<+oue+DGm>Df0B:+CQC7ATMq",
 		},
+		{
+			name: "Adobe",
+			args: args{r: strings.NewReader("This is only a test"), opts: []Option{WithVariant(Adobe)}},
+			want: "<~<+oue+DGm>Df0B:+CQC7ATMq~>",
+		},
+		{
+			name: "Btoa",
+			args: args{r: strings.NewReader("This is only a test"), opts: []Option{WithVariant(Btoa)}},
+			want: testBtoa,
+		},
+		{
+			name: "BtoaSpaces",
+			args: args{r: strings.NewReader("    \x00\x00\x00\x00abc"), opts: []Option{WithVariant(Btoa)}},
+			want: testBtoaSpace,
+		},
+		{
+			name: "BtoaLines",
+			args: args{r: strings.NewReader(strings.Repeat("\x00", 320)), opts: []Option{WithVariant(Btoa)}},
+			want: "xbtoa Begin\n" + strings.Repeat("z", 78) + "\nzz\nxbtoa End N 320 140 E 0 S 140 R 0\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got, _ := io.ReadAll(ToASCII85(tt.args.r)); strings.Compare(string(got), tt.want) != 0 {
+			if got, _ := io.ReadAll(ToASCII85(tt.args.r, tt.args.opts...)); strings.Compare(string(got), tt.want) != 0 {
 				t.Errorf("ToASCII85() = %v, want %v", string(got), tt.want)
 			}
 		})
@@ -35,19 +62,63 @@ func TestFromASCII85(t *testing.T) {
 		r io.Reader
 	}
 	tests := []struct {
-		name string
-		args args
-		want string
+		name    string
+		args    args
+		want    string
+		wantErr error
 	}{
 		{
 			name: "TestOne",
 			args: args{r: strings.NewReader("<+oue+DGm>Df0B:+CQC7ATMq")},
 			want: "This is only a test",
 		},
+		{
+			name: "Adobe",
+			args: args{r: strings.NewReader("\n<~<+oue+DGm>Df0B:\n+CQC7ATMq~>\n")},
+			want: "This is only a test",
+		},
+		{
+			name:    "AdobeUnterminated",
+			args:    args{r: strings.NewReader("<~<+oue+DGm>Df0B:+CQC7ATMq")},
+			want:    "This is only a test",
+			wantErr: ErrFormat,
+		},
+		{
+			name: "Btoa",
+			args: args{r: strings.NewReader(testBtoa)},
+			want: "This is only a test",
+		},
+		{
+			name: "BtoaSpaces",
+			args: args{r: strings.NewReader(testBtoaSpace)},
+			want: "    \x00\x00\x00\x00abc",
+		},
+		{
+			name:    "BtoaBadChecksum",
+			args:    args{r: strings.NewReader(strings.Replace(testBtoa, "E 5f", "E 5e", 1))},
+			want:    "This is only a test",
+			wantErr: ErrChecksum,
+		},
+		{
+			name:    "BtoaBadLength",
+			args:    args{r: strings.NewReader(strings.Replace(testBtoa, "N 19 13", "N 24 18", 1))},
+			want:    "This is only a t",
+			wantErr: ErrChecksum,
+		},
+		{
+			name:    "BtoaMissingEnd",
+			args:    args{r: strings.NewReader("xbtoa Begin\n<+oue+DGm>Df0B:+CQC7ATMqn\n")},
+			want:    "This is only a t",
+			wantErr: ErrFormat,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got, _ := io.ReadAll(FromASCII85(tt.args.r)); strings.Compare(string(got), tt.want) != 0 {
+			got, err := io.ReadAll(FromASCII85(tt.args.r))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromASCII85() error = %v, want %v", err, tt.wantErr)
+			}
+			if strings.Compare(string(got), tt.want) != 0 {
 				t.Errorf("FromASCII85() = %v, want %v", string(got), tt.want)
 			}
 		})
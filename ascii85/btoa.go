@@ -0,0 +1,237 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ascii85
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	btoaBegin   = "xbtoa Begin"
+	btoaEnd     = "xbtoa End"
+	btoaLineLen = 78
+)
+
+// btoaSums holds the checksums that btoa computes over the unencoded data.
+type btoaSums struct {
+	eor, sum, rot uint32
+}
+
+func (s *btoaSums) update(p []byte) {
+	for _, c := range p {
+		s.eor ^= uint32(c)
+		s.sum += uint32(c) + 1
+		if s.rot&0x80000000 != 0 {
+			s.rot = s.rot<<1 + 1
+		} else {
+			s.rot <<= 1
+		}
+		s.rot += uint32(c)
+	}
+}
+
+// btoaWriter encodes data written to it in the btoa format.  A final
+// partial group is padded with zeros; the length in the end line tells the
+// decoder how many bytes are real.
+type btoaWriter struct {
+	w     *bufio.Writer
+	group [4]byte
+	nbuf  int
+	pos   int // The number of characters on the current line.
+	n     uint32
+	sums  btoaSums
+}
+
+func (bw *btoaWriter) put(p ...byte) error {
+	for _, c := range p {
+		if err := bw.w.WriteByte(c); err != nil {
+			return err
+		}
+		if bw.pos++; bw.pos >= btoaLineLen {
+			bw.pos = 0
+			if err := bw.w.WriteByte('\n'); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (bw *btoaWriter) flushGroup() error {
+	v := uint32(bw.group[0])<<24 | uint32(bw.group[1])<<16 | uint32(bw.group[2])<<8 | uint32(bw.group[3])
+	bw.nbuf = 0
+	switch v {
+	case 0:
+		return bw.put('z')
+	case 0x20202020:
+		return bw.put('y')
+	}
+	var dst [5]byte
+	for i := 4; i >= 0; i-- {
+		dst[i] = '!' + byte(v%85)
+		v /= 85
+	}
+	return bw.put(dst[:]...)
+}
+
+func (bw *btoaWriter) Write(p []byte) (int, error) {
+	bw.sums.update(p)
+	bw.n += uint32(len(p))
+	for _, c := range p {
+		bw.group[bw.nbuf] = c
+		if bw.nbuf++; bw.nbuf == len(bw.group) {
+			if err := bw.flushGroup(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// Close flushes any partial group and writes the end line.
+func (bw *btoaWriter) Close() error {
+	if bw.nbuf > 0 {
+		clear(bw.group[bw.nbuf:])
+		if err := bw.flushGroup(); err != nil {
+			return err
+		}
+	}
+	if bw.pos != 0 {
+		if err := bw.w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(bw.w, "%s N %d %x E %x S %x R %x\n", btoaEnd, bw.n, bw.n, bw.sums.eor, bw.sums.sum, bw.sums.rot)
+	return bw.w.Flush()
+}
+
+// toBtoa reads data from r and encodes it in the btoa format.  The encoded
+// data can be read using the returned PipeReader.
+func toBtoa(r io.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		bw := &btoaWriter{w: bufio.NewWriter(rWrtr)}
+		if _, err := bw.w.WriteString(btoaBegin + "\n"); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+			return
+		}
+		if _, err := io.Copy(bw, r); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an io.Reader to a btoa encoder: %w", err))
+			return
+		}
+		if err := bw.Close(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error closing a btoa encoder: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// fromBtoa reads btoa encoded data, starting with the begin line, from br
+// and decodes it.  The last group is withheld until the end line has been
+// read, so that its padding can be removed, and the checksums are verified
+// before the PipeReader is closed.  The decoded data can be read using the
+// returned PipeReader.
+func fromBtoa(br *bufio.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		var (
+			v       uint64
+			ndigits int
+			held    []byte // The most recently decoded group.
+			total   uint32 // The number of bytes decoded before held.
+			sums    btoaSums
+			out     []byte
+		)
+		if _, err := br.ReadString('\n'); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("%w: missing %q line", ErrFormat, btoaEnd))
+			return
+		}
+		emit := func(group ...byte) {
+			if held != nil {
+				out = append(out, held...)
+				total += uint32(len(held))
+			}
+			held = group
+		}
+		for lineNo := 2; ; lineNo++ {
+			line, err := br.ReadBytes('\n')
+			if errors.Is(err, io.EOF) && len(line) == 0 {
+				rWrtr.CloseWithError(fmt.Errorf("%w: missing %q line", ErrFormat, btoaEnd))
+				return
+			} else if err != nil && !errors.Is(err, io.EOF) {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			line = bytes.TrimSpace(line)
+			if bytes.HasPrefix(line, []byte(btoaEnd)) {
+				var n, nx, eor, sum, rot uint64
+				if _, err := fmt.Sscanf(string(line), btoaEnd+" N %d %x E %x S %x R %x", &n, &nx, &eor, &sum, &rot); err != nil {
+					rWrtr.CloseWithError(fmt.Errorf("%w: line %d: malformed %q line: %v", ErrFormat, lineNo, btoaEnd, err))
+					return
+				}
+				if ndigits != 0 {
+					rWrtr.CloseWithError(fmt.Errorf("%w: line %d: data ends within a group", ErrFormat, lineNo))
+					return
+				}
+				if n != nx || n < uint64(total) || n > uint64(total)+uint64(len(held)) {
+					rWrtr.CloseWithError(fmt.Errorf("%w: length %d does not match the data", ErrChecksum, n))
+					return
+				}
+				out = append(out, held[:n-uint64(total)]...)
+				sums.update(out)
+				if _, err := rWrtr.Write(out); err != nil {
+					rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+					return
+				}
+				if uint32(eor) != sums.eor || uint32(sum) != sums.sum || uint32(rot) != sums.rot {
+					rWrtr.CloseWithError(ErrChecksum)
+				}
+				return
+			}
+			for _, c := range line {
+				switch {
+				case isSpace(c):
+					continue
+				case (c == 'z' || c == 'y') && ndigits == 0:
+					if c == 'z' {
+						emit(0, 0, 0, 0)
+					} else {
+						emit(' ', ' ', ' ', ' ')
+					}
+					continue
+				case c < '!' || c > 'u':
+					rWrtr.CloseWithError(fmt.Errorf("%w: line %d: illegal character %q", ErrFormat, lineNo, c))
+					return
+				}
+				v = v*85 + uint64(c-'!')
+				if ndigits++; ndigits == 5 {
+					if v > 0xffffffff {
+						rWrtr.CloseWithError(fmt.Errorf("%w: line %d: group out of range", ErrFormat, lineNo))
+						return
+					}
+					emit(byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+					v, ndigits = 0, 0
+				}
+			}
+			sums.update(out)
+			if _, err := rWrtr.Write(out); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+			out = out[:0]
+		}
+	}()
+
+	return rRdr
+}
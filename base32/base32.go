@@ -0,0 +1,250 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package base32 defines filters to encode/decode data using base32 encoding.
+// The standard (RFC 4648) and extended hex alphabets are supported, with or
+// without padding, as well as Crockford's alphabet with its optional check
+// symbol.  These filters can be connected to other filters via io.Pipes.
+package base32
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// An Encoding selects the base32 alphabet.
+type Encoding int
+
+const (
+	// StdEncoding is the standard base32 alphabet defined in RFC 4648.
+	StdEncoding Encoding = iota
+	// HexEncoding is the extended hex alphabet defined in RFC 4648.
+	HexEncoding
+	// CrockfordEncoding is Douglas Crockford's alphabet, which excludes I,
+	// L, O and U.  It is never padded.  On decode, hyphens are ignored and
+	// I and L are read as 1 and O as 0.
+	CrockfordEncoding
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	checkSymbols      = crockfordAlphabet + "*~$=U"
+)
+
+var (
+	// ErrCheckSymbol is returned when a Crockford check symbol does not
+	// match the data.
+	ErrCheckSymbol = errors.New("base32: Crockford check symbol mismatch")
+	// ErrCheckEncoding is returned when WithCheckSymbol is given with an
+	// encoding other than CrockfordEncoding.
+	ErrCheckEncoding = errors.New("base32: a check symbol requires CrockfordEncoding")
+)
+
+var crockford = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
+// An Option configures the behaviour of ToBase32 and FromBase32.
+type Option func(*options)
+
+type options struct {
+	encoding  Encoding
+	noPadding bool
+	check     bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// base32 returns the encoding/base32 Encoding selected by the options.
+func (o options) base32() *base32.Encoding {
+	var enc *base32.Encoding
+	switch o.encoding {
+	case HexEncoding:
+		enc = base32.HexEncoding
+	case CrockfordEncoding:
+		return crockford
+	default:
+		enc = base32.StdEncoding
+	}
+	if o.noPadding {
+		enc = enc.WithPadding(base32.NoPadding)
+	}
+	return enc
+}
+
+// validate returns an error if the options are not valid.
+func (o options) validate() error {
+	if o.check && o.encoding != CrockfordEncoding {
+		return ErrCheckEncoding
+	}
+	return nil
+}
+
+// WithEncoding selects the alphabet.  The default is StdEncoding.
+func WithEncoding(e Encoding) Option {
+	return func(o *options) {
+		o.encoding = e
+	}
+}
+
+// WithoutPadding omits the '=' padding characters on encode, and expects
+// them to be absent on decode.
+func WithoutPadding() Option {
+	return func(o *options) {
+		o.noPadding = true
+	}
+}
+
+// WithCheckSymbol appends a Crockford check symbol on encode, and verifies
+// and removes it on decode.  It requires CrockfordEncoding; with any other
+// encoding the filters fail with ErrCheckEncoding.
+func WithCheckSymbol() Option {
+	return func(o *options) {
+		o.check = true
+	}
+}
+
+// checkWriter passes Crockford symbols through to w while computing their
+// check value.
+type checkWriter struct {
+	w   io.Writer
+	sum int
+}
+
+func (cw *checkWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		cw.sum = (cw.sum*32 + strings.IndexByte(crockfordAlphabet, b)) % 37
+	}
+	return cw.w.Write(p)
+}
+
+// ToBase32 reads data from r, encodes it using a base32 encoder.
+// The base32 encoded data can be read using the returned PipeReader.
+func ToBase32(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		rWrtr.CloseWithError(err)
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		var w io.Writer = rWrtr
+		var cw *checkWriter
+		if o.check {
+			cw = &checkWriter{w: rWrtr}
+			w = cw
+		}
+		base32W := base32.NewEncoder(o.base32(), w)
+		_, err := io.Copy(base32W, r)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an io.Reader to a base32.Encoder: %w", err))
+			return
+		}
+		if err = base32W.Close(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error closing a base32.Encoder: %w", err))
+			return
+		}
+		if o.check {
+			if _, err = rWrtr.Write([]byte{checkSymbols[cw.sum]}); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing the check symbol to an io.PipeWriter: %w", err))
+			}
+		}
+	}()
+
+	return rRdr
+}
+
+// normalize reads base32 encoded data from r and maps it to the canonical
+// form of the alphabet selected by o, verifying and removing the Crockford
+// check symbol if requested.  The normalized data can be read using the
+// returned PipeReader.
+func normalize(r io.Reader, o options) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		buf := make([]byte, 4096)
+		out := make([]byte, 0, len(buf)+1)
+		sum := 0
+		var held byte // The last symbol, which may be the check symbol.
+		for {
+			n, err := r.Read(buf)
+			out = out[:0]
+			for _, b := range buf[:n] {
+				if 'a' <= b && b <= 'z' {
+					b -= 'a' - 'A'
+				}
+				if o.encoding == CrockfordEncoding {
+					switch b {
+					case '-', ' ', '\t', '\r', '\n':
+						continue
+					case 'I', 'L':
+						b = '1'
+					case 'O':
+						b = '0'
+					}
+					if o.check {
+						if held != 0 {
+							out = append(out, held)
+							sum = (sum*32 + strings.IndexByte(crockfordAlphabet, held)) % 37
+						}
+						held = b
+						continue
+					}
+				}
+				out = append(out, b)
+			}
+			if _, werr := rWrtr.Write(out); werr != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", werr))
+				return
+			}
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+		}
+		if o.check {
+			if held == 0 || strings.IndexByte(checkSymbols, held) != sum {
+				rWrtr.CloseWithError(ErrCheckSymbol)
+			}
+		}
+	}()
+
+	return rRdr
+}
+
+// FromBase32 reads base32 encoded data from r, decodes it using the base32
+// decoder.  Lower case letters are accepted, and for CrockfordEncoding,
+// hyphens are ignored.  The decoded data can be read using the returned
+// PipeReader.
+func FromBase32(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		rWrtr.CloseWithError(err)
+		return rRdr
+	}
+	base32R := base32.NewDecoder(o.base32(), normalize(r, o))
+
+	go func() {
+		defer rWrtr.Close()
+		_, err := io.Copy(rWrtr, base32R)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from a base32.Decoder to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
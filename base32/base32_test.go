@@ -0,0 +1,148 @@
+package base32
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const (
+	testText      = "This is only a test of the ToBase32 filter."
+	testStd       = "KRUGS4ZANFZSA33ONR4SAYJAORSXG5BAN5TCA5DIMUQFI32CMFZWKMZSEBTGS3DUMVZC4==="
+	testHex       = "AHK6ISP0D5PI0RREDHSI0O90EHIN6T10DTJ20T38CKG58RQ2C5PMACPI41J6IR3KCLP2S==="
+	testCrockford = "AHM6JWS0D5SJ0VVEDHWJ0R90EHJQ6X10DXK20X38CMG58VT2C5SPACSJ41K6JV3MCNS2W"
+)
+
+func TestToBase32(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testText)},
+			want: testStd,
+		},
+		{
+			name: "Hex",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithEncoding(HexEncoding)}},
+			want: testHex,
+		},
+		{
+			name: "NoPadding",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithoutPadding()}},
+			want: strings.TrimRight(testStd, "="),
+		},
+		{
+			name: "Crockford",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithEncoding(CrockfordEncoding)}},
+			want: testCrockford,
+		},
+		{
+			name: "CrockfordCheck",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithEncoding(CrockfordEncoding), WithCheckSymbol()}},
+			want: testCrockford + "E",
+		},
+		{
+			name: "CrockfordCheckEmpty",
+			args: args{r: strings.NewReader(""), opts: []Option{WithEncoding(CrockfordEncoding), WithCheckSymbol()}},
+			want: "0",
+		},
+		{
+			name:    "CheckWithoutCrockford",
+			args:    args{r: strings.NewReader(testText), opts: []Option{WithCheckSymbol()}},
+			want:    "",
+			wantErr: ErrCheckEncoding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(ToBase32(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToBase32() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToBase32() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromBase32(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testStd)},
+			want: testText,
+		},
+		{
+			name: "LowerCase",
+			args: args{r: strings.NewReader(strings.ToLower(testStd))},
+			want: testText,
+		},
+		{
+			name: "Hex",
+			args: args{r: strings.NewReader(testHex), opts: []Option{WithEncoding(HexEncoding)}},
+			want: testText,
+		},
+		{
+			name: "NoPadding",
+			args: args{r: strings.NewReader(strings.TrimRight(testStd, "=")), opts: []Option{WithoutPadding()}},
+			want: testText,
+		},
+		{
+			name: "Crockford",
+			args: args{r: strings.NewReader(testCrockford), opts: []Option{WithEncoding(CrockfordEncoding)}},
+			want: testText,
+		},
+		{
+			name: "CrockfordLenient",
+			args: args{r: strings.NewReader("ahm6-jwsO-d5sj-Ovve-dhwj-" + testCrockford[20:]), opts: []Option{WithEncoding(CrockfordEncoding)}},
+			want: testText,
+		},
+		{
+			name: "CrockfordCheck",
+			args: args{r: strings.NewReader(testCrockford + "e"), opts: []Option{WithEncoding(CrockfordEncoding), WithCheckSymbol()}},
+			want: testText,
+		},
+		{
+			name:    "CrockfordBadCheck",
+			args:    args{r: strings.NewReader(testCrockford + "F"), opts: []Option{WithEncoding(CrockfordEncoding), WithCheckSymbol()}},
+			want:    testText,
+			wantErr: ErrCheckSymbol,
+		},
+		{
+			name:    "CheckWithoutCrockford",
+			args:    args{r: strings.NewReader(testHex), opts: []Option{WithEncoding(HexEncoding), WithCheckSymbol()}},
+			want:    "",
+			wantErr: ErrCheckEncoding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromBase32(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromBase32() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromBase32() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/base32
+
+go 1.24.2
@@ -0,0 +1,175 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package base45 defines filters to encode/decode data using base45 encoding,
+// as defined in RFC 9285.  Base45 is designed for QR codes, whose
+// alphanumeric mode holds the 45 characters it uses, and is usually applied
+// to compressed data, such as the output of zlib.ToZlib.  These filters can
+// be connected to other filters via io.Pipes.
+package base45
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+var decodeMap [256]byte
+
+func init() {
+	for i := range decodeMap {
+		decodeMap[i] = 0xff
+	}
+	for i := 0; i < len(alphabet); i++ {
+		decodeMap[alphabet[i]] = byte(i)
+	}
+}
+
+// ErrLength is returned when the encoded data ends with a single character,
+// which can not encode a byte.
+var ErrLength = errors.New("base45: encoded data ends with a single character")
+
+// CorruptInputError is returned when the encoded data holds a character that
+// is not in the alphabet, or a group of characters whose value is too large
+// for the bytes it encodes.  Its value is the offset of the character, or of
+// the start of the group.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "base45: illegal data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// encoder encodes data written to it in base45.
+type encoder struct {
+	w    *bufio.Writer
+	odd  bool // If a byte is waiting for its pair.
+	high byte
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	for i, c := range p {
+		if !e.odd {
+			e.high, e.odd = c, true
+			continue
+		}
+		n := int(e.high)<<8 | int(c)
+		e.odd = false
+		e.w.WriteByte(alphabet[n%45])
+		e.w.WriteByte(alphabet[n/45%45])
+		if err := e.w.WriteByte(alphabet[n/2025]); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close encodes a final single byte and flushes the output.
+func (e *encoder) Close() error {
+	if e.odd {
+		e.w.WriteByte(alphabet[e.high%45])
+		e.w.WriteByte(alphabet[e.high/45])
+	}
+	return e.w.Flush()
+}
+
+// ToBase45 reads data from r, encodes it using base45.
+// The base45 encoded data can be read using the returned PipeReader.
+func ToBase45(r io.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		enc := &encoder{w: bufio.NewWriter(rWrtr)}
+		_, err := io.Copy(enc, r)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an io.Reader to a base45 encoder: %w", err))
+			return
+		}
+		if err = enc.Close(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// FromBase45 reads base45 encoded data from r, decodes it.  Line breaks in
+// the encoded data are ignored; any other character that is not in the
+// alphabet is an error.  The decoded data can be read using the returned
+// PipeReader.
+func FromBase45(r io.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		var (
+			br      = bufio.NewReader(r)
+			bw      = bufio.NewWriter(rWrtr)
+			offset  int64
+			start   int64 // The offset of the current group.
+			n       int
+			scale   = 1
+			ndigits int
+		)
+		fail := func(err error) {
+			bw.Flush()
+			rWrtr.CloseWithError(err)
+		}
+		for {
+			c, err := br.ReadByte()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				fail(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			offset++
+			if c == '\r' || c == '\n' {
+				continue
+			}
+			d := decodeMap[c]
+			if d == 0xff {
+				fail(CorruptInputError(offset - 1))
+				return
+			}
+			if ndigits == 0 {
+				start = offset - 1
+			}
+			n += int(d) * scale
+			scale *= 45
+			if ndigits++; ndigits < 3 {
+				continue
+			}
+			if n > 0xffff {
+				fail(CorruptInputError(start))
+				return
+			}
+			bw.WriteByte(byte(n >> 8))
+			if err = bw.WriteByte(byte(n)); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+			n, scale, ndigits = 0, 1, 0
+		}
+		switch {
+		case ndigits == 1:
+			fail(fmt.Errorf("%w at input byte %d", ErrLength, start))
+			return
+		case ndigits == 2 && n > 0xff:
+			fail(CorruptInputError(start))
+			return
+		case ndigits == 2:
+			bw.WriteByte(byte(n))
+		}
+		if err := bw.Flush(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
@@ -0,0 +1,106 @@
+package base45
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// The test vectors are from RFC 9285.
+func TestToBase45(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("AB")},
+			want: "BB8",
+		},
+		{
+			name: "TestTwo",
+			args: args{r: strings.NewReader("Hello!!")},
+			want: "%69 VD92EX0",
+		},
+		{
+			name: "TestThree",
+			args: args{r: strings.NewReader("base-45")},
+			want: "UJCLQE7W581",
+		},
+		{
+			name: "Empty",
+			args: args{r: strings.NewReader("")},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToBase45(tt.args.r)); string(got) != tt.want {
+				t.Errorf("ToBase45() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromBase45(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("QED8WEX0")},
+			want: "ietf!",
+		},
+		{
+			name: "TestTwo",
+			args: args{r: strings.NewReader("%69 VD92EX0\n")},
+			want: "Hello!!",
+		},
+		{
+			name:    "TripletOutOfRange",
+			args:    args{r: strings.NewReader("BB8GGW")},
+			want:    "AB",
+			wantErr: CorruptInputError(3),
+		},
+		{
+			name:    "PairOutOfRange",
+			args:    args{r: strings.NewReader("BB8:6")},
+			want:    "AB",
+			wantErr: CorruptInputError(3),
+		},
+		{
+			name:    "InvalidCharacter",
+			args:    args{r: strings.NewReader("BB8a")},
+			want:    "AB",
+			wantErr: CorruptInputError(3),
+		},
+		{
+			name:    "SingleCharacter",
+			args:    args{r: strings.NewReader("BB8B")},
+			want:    "AB",
+			wantErr: ErrLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromBase45(tt.args.r))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromBase45() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromBase45() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
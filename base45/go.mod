@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/base45
+
+go 1.24.2
@@ -0,0 +1,194 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package base58 defines filters to encode/decode data using base58
+// encoding, with the Bitcoin or Flickr alphabets, and optionally with the
+// Base58Check checksum.  These filters can be connected to other filters via
+// io.Pipes.
+//
+// Base58 treats its input as one big number, so, unlike most of the filters,
+// these filters must read all of their input before they can write any
+// output, and the time they take grows faster than the size of the input.
+// The data is therefore limited to DefaultMaxSize bytes unless WithMaxSize
+// is given.  Larger data should be split into chunks that are encoded
+// separately.
+package base58
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// An Alphabet is the ordered set of 58 characters used by an encoding.
+type Alphabet string
+
+const (
+	// BitcoinAlphabet is the alphabet used by Bitcoin addresses.
+	BitcoinAlphabet Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// FlickrAlphabet is the alphabet used by Flickr short URLs.
+	FlickrAlphabet Alphabet = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+// DefaultMaxSize is the default limit on the number of bytes of data: the
+// number of bytes read by the encoder, and the number of bytes encoded in
+// the input of the decoder.
+const DefaultMaxSize = 64 << 10
+
+// ChecksumSize is the number of checksum bytes that Base58Check appends.
+const ChecksumSize = 4
+
+var (
+	// ErrTooLarge is returned when the input is longer than the maximum
+	// size.
+	ErrTooLarge = errors.New("base58: input too large")
+	// ErrAlphabet is returned when the alphabet given to WithAlphabet does
+	// not hold 58 distinct characters.
+	ErrAlphabet = errors.New("base58: invalid alphabet")
+	// ErrChecksum is returned when the Base58Check checksum does not match
+	// the data.
+	ErrChecksum = errors.New("base58: checksum mismatch")
+)
+
+// CorruptInputError is returned when the encoded data holds a character that
+// is not in the alphabet.  Its value is the offset of the character.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "base58: illegal data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// An Option configures the behaviour of ToBase58 and FromBase58.
+type Option func(*options)
+
+type options struct {
+	alphabet Alphabet
+	check    bool
+	maxSize  int64
+}
+
+func newOptions(opts []Option) options {
+	o := options{alphabet: BitcoinAlphabet, maxSize: DefaultMaxSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithAlphabet selects the alphabet.  The default is BitcoinAlphabet.
+func WithAlphabet(a Alphabet) Option {
+	return func(o *options) {
+		o.alphabet = a
+	}
+}
+
+// WithCheck selects Base58Check: ToBase58 appends the first 4 bytes of the
+// double SHA-256 hash of the data before encoding it, and FromBase58
+// verifies and removes them.  Any version byte is part of the data.
+func WithCheck() Option {
+	return func(o *options) {
+		o.check = true
+	}
+}
+
+// WithMaxSize sets the limit on the number of bytes of data.  The decoder
+// allows as much encoded input as n bytes can be encoded in.
+func WithMaxSize(n int64) Option {
+	return func(o *options) {
+		o.maxSize = n
+	}
+}
+
+// checksum returns the Base58Check checksum of data.
+func checksum(data []byte) []byte {
+	h := sha256.Sum256(data)
+	h = sha256.Sum256(h[:])
+	return h[:ChecksumSize]
+}
+
+// validate returns an error if the options are not valid.
+func (o options) validate() error {
+	if err := checkAlphabet(string(o.alphabet), 58); err != nil {
+		return fmt.Errorf("%w: %v", ErrAlphabet, err)
+	}
+	return nil
+}
+
+// ToBase58 reads data from r, encodes it using base58.
+// The base58 encoded data can be read using the returned PipeReader.
+func ToBase58(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		rWrtr.CloseWithError(err)
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		data, err := readAll(r, o.maxSize)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		if o.check {
+			data = append(data, checksum(data)...)
+		}
+		if _, err = rWrtr.Write(encode(data, string(o.alphabet))); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// FromBase58 reads base58 encoded data from r, decodes it.  Leading and
+// trailing white space is ignored.  The decoded data can be read using the
+// returned PipeReader.
+func FromBase58(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		rWrtr.CloseWithError(err)
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		maxSize := o.maxSize
+		if o.check {
+			maxSize += ChecksumSize
+		}
+		encoded, off, err := readEncoded(r, maxSize, string(o.alphabet))
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		data, bad := decode(encoded, string(o.alphabet))
+		if bad >= 0 {
+			rWrtr.CloseWithError(CorruptInputError(off + bad))
+			return
+		}
+		if o.check {
+			if len(data) < ChecksumSize {
+				rWrtr.CloseWithError(fmt.Errorf("%w: the data is shorter than the checksum", ErrChecksum))
+				return
+			}
+			sum := data[len(data)-ChecksumSize:]
+			data = data[:len(data)-ChecksumSize]
+			if !bytes.Equal(sum, checksum(data)) {
+				rWrtr.CloseWithError(ErrChecksum)
+				return
+			}
+		}
+		if _, err = rWrtr.Write(data); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
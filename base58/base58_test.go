@@ -0,0 +1,164 @@
+package base58
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// testAddress is a Bitcoin address, with its version byte, hash and
+// Base58Check checksum.
+const (
+	testAddress = "1PMycacnJaSqwwJqjawXBErnLsZ7RkXUAs"
+	testPayload = "\x00\xf5\x4a\x58\x51\xe9\x37\x2b\x87\x81\x0a\x8e\x60\xcd\xd2\xe7\xcf\xd8\x0b\x6e\x31"
+)
+
+func TestToBase58(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("Hello World!")},
+			want: "2NEpo7TZRRrLZSi2U",
+		},
+		{
+			name: "LeadingZeros",
+			args: args{r: strings.NewReader("\x00\x00\x28\x7f\xb4\xcd")},
+			want: "11233QC4",
+		},
+		{
+			name: "AllZeros",
+			args: args{r: strings.NewReader("\x00\x00\x00")},
+			want: "111",
+		},
+		{
+			name: "Empty",
+			args: args{r: strings.NewReader("")},
+			want: "",
+		},
+		{
+			name: "Flickr",
+			args: args{r: strings.NewReader("Hello World!"), opts: []Option{WithAlphabet(FlickrAlphabet)}},
+			want: "2nePN7syqqRkyrH2t",
+		},
+		{
+			name: "Check",
+			args: args{r: strings.NewReader(testPayload), opts: []Option{WithCheck()}},
+			want: testAddress,
+		},
+		{
+			name:    "TooLarge",
+			args:    args{r: strings.NewReader("Hello World!"), opts: []Option{WithMaxSize(11)}},
+			want:    "",
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "ShortAlphabet",
+			args:    args{r: strings.NewReader("Hello World!"), opts: []Option{WithAlphabet("abc")}},
+			want:    "",
+			wantErr: ErrAlphabet,
+		},
+		{
+			name:    "LongAlphabet",
+			args:    args{r: strings.NewReader("Hello World!"), opts: []Option{WithAlphabet(BitcoinAlphabet + "+/")}},
+			want:    "",
+			wantErr: ErrAlphabet,
+		},
+		{
+			name:    "RepeatedAlphabet",
+			args:    args{r: strings.NewReader("Hello World!"), opts: []Option{WithAlphabet(BitcoinAlphabet[:len(BitcoinAlphabet)-1] + BitcoinAlphabet[:1])}},
+			want:    "",
+			wantErr: ErrAlphabet,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(ToBase58(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToBase58() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToBase58() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromBase58(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("2NEpo7TZRRrLZSi2U\n")},
+			want: "Hello World!",
+		},
+		{
+			name: "LeadingZeros",
+			args: args{r: strings.NewReader("11233QC4")},
+			want: "\x00\x00\x28\x7f\xb4\xcd",
+		},
+		{
+			name: "AllZeros",
+			args: args{r: strings.NewReader("111")},
+			want: "\x00\x00\x00",
+		},
+		{
+			name: "Flickr",
+			args: args{r: strings.NewReader("2nePN7syqqRkyrH2t"), opts: []Option{WithAlphabet(FlickrAlphabet)}},
+			want: "Hello World!",
+		},
+		{
+			name: "Check",
+			args: args{r: strings.NewReader(testAddress), opts: []Option{WithCheck()}},
+			want: testPayload,
+		},
+		{
+			name:    "BadCheck",
+			args:    args{r: strings.NewReader("1PMycacnJaSqwwJqjawXBErnLsZ7RkXUAt"), opts: []Option{WithCheck()}},
+			wantErr: ErrChecksum,
+		},
+		{
+			name:    "InvalidCharacter",
+			args:    args{r: strings.NewReader(" 2NEpo0TZRRrLZSi2U")},
+			wantErr: CorruptInputError(6),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromBase58(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromBase58() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromBase58() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBase58RoundTripMaxSize(t *testing.T) {
+	// All 0xff bytes give the longest encoding of DefaultMaxSize bytes.
+	want := bytes.Repeat([]byte{0xff}, DefaultMaxSize)
+	got, err := io.ReadAll(FromBase58(ToBase58(bytes.NewReader(want))))
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("FromBase58(ToBase58()) = %d bytes, %v, want %d bytes", len(got), err, len(want))
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/base58
+
+go 1.24.2
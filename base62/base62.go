@@ -0,0 +1,151 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package base62 defines filters to encode/decode data using base62
+// encoding, which uses only letters and digits.  These filters can be
+// connected to other filters via io.Pipes.
+//
+// Base62 treats its input as one big number, so, unlike most of the filters,
+// these filters must read all of their input before they can write any
+// output, and the time they take grows faster than the size of the input.
+// The data is therefore limited to DefaultMaxSize bytes unless WithMaxSize
+// is given.  Larger data should be split into chunks that are encoded
+// separately.
+package base62
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// An Alphabet is the ordered set of 62 characters used by an encoding.
+type Alphabet string
+
+const (
+	// StdAlphabet is the digits, then the upper case letters, then the
+	// lower case letters, as used by GMP.
+	StdAlphabet Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// InvertedAlphabet is the digits, then the lower case letters, then
+	// the upper case letters.
+	InvertedAlphabet Alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// DefaultMaxSize is the default limit on the number of bytes of data: the
+// number of bytes read by the encoder, and the number of bytes encoded in
+// the input of the decoder.
+const DefaultMaxSize = 64 << 10
+
+var (
+	// ErrTooLarge is returned when the input is longer than the maximum
+	// size.
+	ErrTooLarge = errors.New("base62: input too large")
+	// ErrAlphabet is returned when the alphabet given to WithAlphabet does
+	// not hold 62 distinct characters.
+	ErrAlphabet = errors.New("base62: invalid alphabet")
+)
+
+// CorruptInputError is returned when the encoded data holds a character that
+// is not in the alphabet.  Its value is the offset of the character.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "base62: illegal data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// An Option configures the behaviour of ToBase62 and FromBase62.
+type Option func(*options)
+
+type options struct {
+	alphabet Alphabet
+	maxSize  int64
+}
+
+func newOptions(opts []Option) options {
+	o := options{alphabet: StdAlphabet, maxSize: DefaultMaxSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithAlphabet selects the alphabet.  The default is StdAlphabet.
+func WithAlphabet(a Alphabet) Option {
+	return func(o *options) {
+		o.alphabet = a
+	}
+}
+
+// WithMaxSize sets the limit on the number of bytes of data.  The decoder
+// allows as much encoded input as n bytes can be encoded in.
+func WithMaxSize(n int64) Option {
+	return func(o *options) {
+		o.maxSize = n
+	}
+}
+
+// validate returns an error if the options are not valid.
+func (o options) validate() error {
+	if err := checkAlphabet(string(o.alphabet), 62); err != nil {
+		return fmt.Errorf("%w: %v", ErrAlphabet, err)
+	}
+	return nil
+}
+
+// ToBase62 reads data from r, encodes it using base62.
+// The base62 encoded data can be read using the returned PipeReader.
+func ToBase62(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		rWrtr.CloseWithError(err)
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		data, err := readAll(r, o.maxSize)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		if _, err = rWrtr.Write(encode(data, string(o.alphabet))); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// FromBase62 reads base62 encoded data from r, decodes it.  Leading and
+// trailing white space is ignored.  The decoded data can be read using the
+// returned PipeReader.
+func FromBase62(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		rWrtr.CloseWithError(err)
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		encoded, off, err := readEncoded(r, o.maxSize, string(o.alphabet))
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		data, bad := decode(encoded, string(o.alphabet))
+		if bad >= 0 {
+			rWrtr.CloseWithError(CorruptInputError(off + bad))
+			return
+		}
+		if _, err = rWrtr.Write(data); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
@@ -0,0 +1,132 @@
+package base62
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestToBase62(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("Hello World!")},
+			want: "T8dgcjRGkZ3aysdN",
+		},
+		{
+			name: "LeadingZeros",
+			args: args{r: strings.NewReader("\x00\x00\x28\x7f\xb4\xcd")},
+			want: "00jyw3x",
+		},
+		{
+			name: "Empty",
+			args: args{r: strings.NewReader("")},
+			want: "",
+		},
+		{
+			name: "Inverted",
+			args: args{r: strings.NewReader("Hello World!"), opts: []Option{WithAlphabet(InvertedAlphabet)}},
+			want: "t8DGCJrgKz3AYSDn",
+		},
+		{
+			name:    "TooLarge",
+			args:    args{r: strings.NewReader("Hello World!"), opts: []Option{WithMaxSize(11)}},
+			want:    "",
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "ShortAlphabet",
+			args:    args{r: strings.NewReader("Hello World!"), opts: []Option{WithAlphabet("abc")}},
+			want:    "",
+			wantErr: ErrAlphabet,
+		},
+		{
+			name:    "LongAlphabet",
+			args:    args{r: strings.NewReader("Hello World!"), opts: []Option{WithAlphabet(StdAlphabet + "+/")}},
+			want:    "",
+			wantErr: ErrAlphabet,
+		},
+		{
+			name:    "RepeatedAlphabet",
+			args:    args{r: strings.NewReader("Hello World!"), opts: []Option{WithAlphabet(StdAlphabet[:len(StdAlphabet)-1] + StdAlphabet[:1])}},
+			want:    "",
+			wantErr: ErrAlphabet,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(ToBase62(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToBase62() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToBase62() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromBase62(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("T8dgcjRGkZ3aysdN\n")},
+			want: "Hello World!",
+		},
+		{
+			name: "LeadingZeros",
+			args: args{r: strings.NewReader("00jyw3x")},
+			want: "\x00\x00\x28\x7f\xb4\xcd",
+		},
+		{
+			name: "Inverted",
+			args: args{r: strings.NewReader("t8DGCJrgKz3AYSDn"), opts: []Option{WithAlphabet(InvertedAlphabet)}},
+			want: "Hello World!",
+		},
+		{
+			name:    "InvalidCharacter",
+			args:    args{r: strings.NewReader("T8dgc-RGkZ3aysdN")},
+			wantErr: CorruptInputError(5),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromBase62(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromBase62() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromBase62() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBase62RoundTripMaxSize(t *testing.T) {
+	// All 0xff bytes give the longest encoding of DefaultMaxSize bytes.
+	want := bytes.Repeat([]byte{0xff}, DefaultMaxSize)
+	got, err := io.ReadAll(FromBase62(ToBase62(bytes.NewReader(want))))
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("FromBase62(ToBase62()) = %d bytes, %v, want %d bytes", len(got), err, len(want))
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/base62
+
+go 1.24.2
@@ -0,0 +1,116 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package base62
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// bigDigits are the digits used by big.Int for bases up to 62.
+const bigDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// maxSpace is the amount of white space allowed around the encoded data.
+const maxSpace = 1024
+
+// checkAlphabet returns an error if a does not hold exactly radix distinct
+// characters.
+func checkAlphabet(a string, radix int) error {
+	if len(a) != radix {
+		return fmt.Errorf("the alphabet has %d characters, not %d", len(a), radix)
+	}
+	for i := 0; i < len(a); i++ {
+		if strings.IndexByte(a[i+1:], a[i]) >= 0 {
+			return fmt.Errorf("the alphabet repeats %q", a[i])
+		}
+	}
+	return nil
+}
+
+// encodedLen returns the maximum length of n bytes encoded in radix.
+func encodedLen(n int64, radix int) int64 {
+	return int64(math.Ceil(float64(n)*8/math.Log2(float64(radix)))) + 1
+}
+
+// readAll reads all of r, up to maxSize bytes.  If there is more, it returns
+// ErrTooLarge.
+func readAll(r io.Reader, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading from an io.Reader: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, maxSize)
+	}
+	return data, nil
+}
+
+// readEncoded reads all of r, which holds at most maxSize bytes encoded in
+// the radix given by the length of a, and returns it with the leading and
+// trailing white space removed, along with the offset of the result in the
+// input.  If there is more, it returns ErrTooLarge.
+func readEncoded(r io.Reader, maxSize int64, a string) ([]byte, int, error) {
+	maxLen := encodedLen(maxSize, len(a))
+	encoded, err := io.ReadAll(io.LimitReader(r, maxLen+maxSpace+1))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading from an io.Reader: %w", err)
+	}
+	trimmed := bytes.TrimLeft(encoded, " \t\r\n")
+	off := len(encoded) - len(trimmed)
+	trimmed = bytes.TrimRight(trimmed, " \t\r\n")
+	if int64(len(trimmed)) > maxLen || int64(len(encoded)) > maxLen+maxSpace {
+		return nil, 0, fmt.Errorf("%w: more than %d bytes of encoded data", ErrTooLarge, maxLen)
+	}
+	return trimmed, off, nil
+}
+
+// encode returns data encoded using the alphabet a.  The data is treated
+// as one big number written in the radix given by the length of a, with
+// each leading zero byte written as the first character of a.
+func encode(data []byte, a string) []byte {
+	zeros := len(data) - len(bytes.TrimLeft(data, "\x00"))
+	var n big.Int
+	n.SetBytes(data[zeros:])
+	digits := ""
+	if n.Sign() != 0 {
+		digits = n.Text(len(a))
+	}
+	out := bytes.Repeat([]byte{a[0]}, zeros+len(digits))
+	for i := 0; i < len(digits); i++ {
+		out[zeros+i] = a[strings.IndexByte(bigDigits, digits[i])]
+	}
+	return out
+}
+
+// decode returns the data encoded in s using the alphabet a.  If s holds a
+// character that is not in a, decode returns its index in s as bad;
+// otherwise bad is -1.
+func decode(s []byte, a string) (data []byte, bad int) {
+	var digitOf [256]int
+	for i := range digitOf {
+		digitOf[i] = -1
+	}
+	for i := 0; i < len(a); i++ {
+		digitOf[a[i]] = i
+	}
+	zeros := len(s) - len(bytes.TrimLeft(s, a[:1]))
+	digits := make([]byte, len(s)-zeros)
+	for i, c := range s[zeros:] {
+		d := digitOf[c]
+		if d < 0 {
+			return nil, zeros + i
+		}
+		digits[i] = bigDigits[d]
+	}
+	var n big.Int
+	if len(digits) > 0 {
+		n.SetString(string(digits), len(a))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), -1
+}
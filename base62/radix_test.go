@@ -0,0 +1,50 @@
+package base62
+
+import (
+	"bytes"
+	"testing"
+)
+
+const testRadixAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+func TestCheckAlphabet(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       string
+		radix   int
+		wantErr bool
+	}{
+		{name: "TestOne", a: testRadixAlphabet, radix: 62},
+		{name: "Short", a: "abc", radix: 62, wantErr: true},
+		{name: "Long", a: testRadixAlphabet + "+/", radix: 62, wantErr: true},
+		{name: "Repeated", a: testRadixAlphabet[:61] + "0", radix: 62, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkAlphabet(tt.a, tt.radix); (err != nil) != tt.wantErr {
+				t.Errorf("checkAlphabet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodedLen(t *testing.T) {
+	for _, radix := range []int{58, 62} {
+		for _, n := range []int{0, 1, 2, 31, 32, 100, 1000} {
+			data := bytes.Repeat([]byte{0xff}, n)
+			if got, max := len(encode(data, testRadixAlphabet[:radix])), encodedLen(int64(n), radix); int64(got) > max {
+				t.Errorf("len(encode(%d bytes)) in radix %d = %d, more than encodedLen() = %d", n, radix, got, max)
+			}
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	data, bad := decode([]byte("00A"), testRadixAlphabet)
+	if bad != -1 || !bytes.Equal(data, []byte{0, 0, 10}) {
+		t.Errorf("decode() = %v, %d, want %v, -1", data, bad, []byte{0, 0, 10})
+	}
+	if _, bad = decode([]byte("00A+"), testRadixAlphabet); bad != 3 {
+		t.Errorf("decode() bad = %d, want 3", bad)
+	}
+}
@@ -3,20 +3,150 @@
 // license that can be found in the LICENSE file.
 
 // Package base64 defines filters to encode/decode data base64 encoding.
-// These filters can be connected to other filters via io.Pipes.
+// The standard and URL safe alphabets are supported, with or without
+// padding.  These filters can be connected to other filters via io.Pipes.
 package base64
 
 import (
+	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 )
 
+// An Encoding selects the base64 alphabet and padding.
+type Encoding int
+
+const (
+	// StdEncoding is the standard base64 encoding defined in RFC 4648.
+	StdEncoding Encoding = iota
+	// URLEncoding is the URL and file name safe encoding defined in RFC 4648.
+	URLEncoding
+	// RawStdEncoding is StdEncoding without padding.
+	RawStdEncoding
+	// RawURLEncoding is URLEncoding without padding.
+	RawURLEncoding
+)
+
+// An Option configures the behaviour of ToBase64 and FromBase64.
+type Option func(*options)
+
+type options struct {
+	encoding Encoding
+	mime     bool
+	auto     bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// base64 returns the encoding/base64 Encoding selected by the options.
+func (o options) base64() *base64.Encoding {
+	switch o.encoding {
+	case URLEncoding:
+		return base64.URLEncoding
+	case RawStdEncoding:
+		return base64.RawStdEncoding
+	case RawURLEncoding:
+		return base64.RawURLEncoding
+	default:
+		return base64.StdEncoding
+	}
+}
+
+// WithEncoding selects the alphabet and padding.  The default is
+// StdEncoding.
+func WithEncoding(e Encoding) Option {
+	return func(o *options) {
+		o.encoding = e
+	}
+}
+
+// WithMIME makes FromBase64 ignore line breaks and other white space
+// anywhere in its input, as MIME bodies require.  Without it, only line
+// breaks are ignored.
+func WithMIME() Option {
+	return func(o *options) {
+		o.mime = true
+	}
+}
+
+// WithAuto makes FromBase64 accept both the standard and the URL safe
+// alphabets, with or without padding at the end of the data, ignoring the
+// selected encoding.  It has no effect on ToBase64.
+func WithAuto() Option {
+	return func(o *options) {
+		o.auto = true
+	}
+}
+
+// normalize reads base64 encoded data from r, removes white space if
+// o.mime is set, and maps the URL safe alphabet to the standard one and
+// removes the padding at the end of the data if o.auto is set.  Padding
+// anywhere else is kept, so that the decoder rejects it.  The normalized
+// data can be read using the returned PipeReader.
+func normalize(r io.Reader, o options) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		buf := make([]byte, 4096)
+		out := make([]byte, 0, len(buf)+2)
+		pad := 0 // The number of '=' held back in case they end the data.
+		for {
+			n, err := r.Read(buf)
+			out = out[:0]
+			for _, b := range buf[:n] {
+				switch {
+				case o.mime && (b == ' ' || b == '\t' || b == '\r' || b == '\n' || b == '\v' || b == '\f'):
+					continue
+				case o.auto && b == '=':
+					pad++
+					continue
+				case o.auto && (b == '\r' || b == '\n'):
+				case o.auto && pad > 0:
+					out = append(out, bytes.Repeat([]byte{'='}, pad)...)
+					pad = 0
+				}
+				switch {
+				case o.auto && b == '-':
+					b = '+'
+				case o.auto && b == '_':
+					b = '/'
+				}
+				out = append(out, b)
+			}
+			if errors.Is(err, io.EOF) && pad > 2 {
+				// Too much padding to remove; let the decoder reject it.
+				out = append(out, bytes.Repeat([]byte{'='}, pad)...)
+			}
+			if _, werr := rWrtr.Write(out); werr != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", werr))
+				return
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			} else if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+		}
+	}()
+
+	return rRdr
+}
+
 // ToBase64 reads data from r, encodes it using a base64 encoder.
 // The base64 encoded data can be read using the returned PipeReader.
-func ToBase64(r io.Reader) *io.PipeReader {
+func ToBase64(r io.Reader, opts ...Option) *io.PipeReader {
 	rRdr, rWrtr := io.Pipe()
-	base64W := base64.NewEncoder(base64.StdEncoding, rWrtr)
+	base64W := base64.NewEncoder(newOptions(opts).base64(), rWrtr)
 
 	go func() {
 		defer rWrtr.Close()
@@ -30,11 +160,19 @@ func ToBase64(r io.Reader) *io.PipeReader {
 	return rRdr
 }
 
-// FromBase64 reads ascii85 encoded data from r, decodes it using the base64
+// FromBase64 reads base64 encoded data from r, decodes it using the base64
 // decoder.  The decoded data can be read using the returned PipeReader.
-func FromBase64(r io.Reader) *io.PipeReader {
+func FromBase64(r io.Reader, opts ...Option) *io.PipeReader {
 	rRdr, rWrtr := io.Pipe()
-	base64R := base64.NewDecoder(base64.StdEncoding, r)
+	o := newOptions(opts)
+	enc := o.base64()
+	if o.auto {
+		enc = base64.RawStdEncoding
+	}
+	if o.mime || o.auto {
+		r = normalize(r, o)
+	}
+	base64R := base64.NewDecoder(enc, r)
 
 	go func() {
 		defer rWrtr.Close()
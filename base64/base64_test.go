@@ -6,9 +6,16 @@ import (
 	"testing"
 )
 
+const (
+	testText = "Subjects?>? ~~~ This is only a test.!"
+	testStd  = "U3ViamVjdHM/Pj8gfn5+IFRoaXMgaXMgb25seSBhIHRlc3QuIQ=="
+	testURL  = "U3ViamVjdHM_Pj8gfn5-IFRoaXMgaXMgb25seSBhIHRlc3QuIQ=="
+)
+
 func TestToBase64(t *testing.T) {
 	type args struct {
-		r io.Reader
+		r    io.Reader
+		opts []Option
 	}
 	tests := []struct {
 		name string
@@ -20,10 +27,30 @@ func TestToBase64(t *testing.T) {
 			args: args{r: strings.NewReader("This is only a test of the ToBase64 filter.")},
 			want: "VGhpcyBpcyBvbmx5IGEgdGVzdCBvZiB0aGUgVG9CYXNlNjQgZmlsdGVyLg==",
 		},
+		{
+			name: "URLEncoding",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithEncoding(URLEncoding)}},
+			want: testURL,
+		},
+		{
+			name: "RawStdEncoding",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithEncoding(RawStdEncoding)}},
+			want: strings.TrimRight(testStd, "="),
+		},
+		{
+			name: "RawURLEncoding",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithEncoding(RawURLEncoding)}},
+			want: strings.TrimRight(testURL, "="),
+		},
+		{
+			name: "AutoIgnored",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithAuto(), WithEncoding(URLEncoding)}},
+			want: testURL,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got, _ := io.ReadAll(ToBase64(tt.args.r)); strings.Compare(string(got), tt.want) != 0 {
+			if got, _ := io.ReadAll(ToBase64(tt.args.r, tt.args.opts...)); strings.Compare(string(got), tt.want) != 0 {
 				t.Errorf("ToBase64() = %v, want %v", string(got), tt.want)
 			}
 		})
@@ -32,22 +59,64 @@ func TestToBase64(t *testing.T) {
 
 func TestFromBase64(t *testing.T) {
 	type args struct {
-		r io.Reader
+		r    io.Reader
+		opts []Option
 	}
 	tests := []struct {
-		name string
-		args args
-		want string
+		name    string
+		args    args
+		want    string
+		wantErr bool
 	}{
 		{
 			name: "TestOne",
 			args: args{r: strings.NewReader("VGhpcyBpcyBvbmx5IGEgdGVzdCBvZiB0aGUgVG9CYXNlNjQgZmlsdGVyLg==")},
 			want: "This is only a test of the ToBase64 filter.",
 		},
+		{
+			name: "URLEncoding",
+			args: args{r: strings.NewReader(testURL), opts: []Option{WithEncoding(URLEncoding)}},
+			want: testText,
+		},
+		{
+			name: "RawURLEncoding",
+			args: args{r: strings.NewReader(strings.TrimRight(testURL, "=")), opts: []Option{WithEncoding(RawURLEncoding)}},
+			want: testText,
+		},
+		{
+			name:    "StrayWhiteSpace",
+			args:    args{r: strings.NewReader(" U3ViamVjdHM/Pj8g\tfn5+IFRo aXMgaXMgb25s\r\neSBhIHRlc3QuIQ==\n")},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "MIME",
+			args: args{r: strings.NewReader(" U3ViamVjdHM/Pj8g\tfn5+IFRo aXMgaXMgb25s\r\neSBhIHRlc3QuIQ==\n"), opts: []Option{WithMIME()}},
+			want: testText,
+		},
+		{
+			name: "AutoStd",
+			args: args{r: strings.NewReader(testStd), opts: []Option{WithAuto()}},
+			want: testText,
+		},
+		{
+			name: "AutoRawURL",
+			args: args{r: strings.NewReader(strings.TrimRight(testURL, "=")), opts: []Option{WithAuto()}},
+			want: testText,
+		},
+		{
+			name: "AutoMIME",
+			args: args{r: strings.NewReader("U3ViamVjdHM_Pj8gfn5-IFRo\naXMgaXMgb25s eSBhIHRlc3QuIQ"), opts: []Option{WithAuto(), WithMIME()}},
+			want: testText,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got, _ := io.ReadAll(FromBase64(tt.args.r)); strings.Compare(string(got), tt.want) != 0 {
+			got, err := io.ReadAll(FromBase64(tt.args.r, tt.args.opts...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromBase64() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Compare(string(got), tt.want) != 0 {
 				t.Errorf("FromBase64() = %v, want %v", string(got), tt.want)
 			}
 		})
@@ -0,0 +1,164 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package base91 defines filters to encode/decode data using the basE91
+// encoding by Joachim Henke.  basE91 packs 13 or 14 bits into each pair of
+// characters, giving an overhead of at most 23% compared to base64's 33%.
+// These filters can be connected to other filters via io.Pipes.
+package base91
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!#$%&()*+,./:;<=>?@[]^_`{|}~\""
+
+var decodeMap [256]byte
+
+func init() {
+	for i := range decodeMap {
+		decodeMap[i] = 0xff
+	}
+	for i := 0; i < len(alphabet); i++ {
+		decodeMap[alphabet[i]] = byte(i)
+	}
+}
+
+// CorruptInputError is returned when the encoded data holds a character that
+// is not in the alphabet and is not white space.  Its value is the offset of
+// the character.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "base91: illegal data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// encoder encodes data written to it in basE91.
+type encoder struct {
+	w     *bufio.Writer
+	queue uint32 // The bits waiting to be encoded.
+	nbits uint
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	for i, c := range p {
+		e.queue |= uint32(c) << e.nbits
+		e.nbits += 8
+		if e.nbits <= 13 {
+			continue
+		}
+		v := e.queue & 8191
+		if v > 88 {
+			e.queue >>= 13
+			e.nbits -= 13
+		} else {
+			v = e.queue & 16383
+			e.queue >>= 14
+			e.nbits -= 14
+		}
+		e.w.WriteByte(alphabet[v%91])
+		if err := e.w.WriteByte(alphabet[v/91]); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close encodes any remaining bits and flushes the output.
+func (e *encoder) Close() error {
+	if e.nbits > 0 {
+		e.w.WriteByte(alphabet[e.queue%91])
+		if e.nbits > 7 || e.queue > 90 {
+			e.w.WriteByte(alphabet[e.queue/91])
+		}
+	}
+	return e.w.Flush()
+}
+
+// ToBase91 reads data from r, encodes it using basE91.
+// The basE91 encoded data can be read using the returned PipeReader.
+func ToBase91(r io.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		enc := &encoder{w: bufio.NewWriter(rWrtr)}
+		_, err := io.Copy(enc, r)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an io.Reader to a basE91 encoder: %w", err))
+			return
+		}
+		if err = enc.Close(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// FromBase91 reads basE91 encoded data from r, decodes it.  White space in
+// the encoded data is ignored.  The decoded data can be read using the
+// returned PipeReader.
+func FromBase91(r io.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		var (
+			br     = bufio.NewReader(r)
+			bw     = bufio.NewWriter(rWrtr)
+			offset int64
+			queue  uint32
+			nbits  uint
+			v      = -1 // The first character of a pair, or -1.
+		)
+		for ; ; offset++ {
+			c, err := br.ReadByte()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				bw.Flush()
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			d := decodeMap[c]
+			if d == 0xff {
+				if c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\v' || c == '\f' {
+					continue
+				}
+				bw.Flush()
+				rWrtr.CloseWithError(CorruptInputError(offset))
+				return
+			}
+			if v < 0 {
+				v = int(d)
+				continue
+			}
+			v += int(d) * 91
+			queue |= uint32(v) << nbits
+			if v&8191 > 88 {
+				nbits += 13
+			} else {
+				nbits += 14
+			}
+			for ; nbits > 7; nbits -= 8 {
+				bw.WriteByte(byte(queue))
+				queue >>= 8
+			}
+			v = -1
+		}
+		if v >= 0 {
+			bw.WriteByte(byte(queue | uint32(v)<<nbits))
+		}
+		if err := bw.Flush(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
@@ -0,0 +1,92 @@
+package base91
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestToBase91(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("This is only a test of the ToBase91 filter.")},
+			want: "nX,<:WRT%yc%BQmLGB%gO[yCxT0tJF8j44.2k)8eJ3Zt(PLmfPG3L",
+		},
+		{
+			name: "TestTwo",
+			args: args{r: strings.NewReader("Hello, World!")},
+			want: ">OwJh>}AQ;r@@Y?F",
+		},
+		{
+			name: "Zero",
+			args: args{r: strings.NewReader("\x00")},
+			want: "AA",
+		},
+		{
+			name: "Ones",
+			args: args{r: strings.NewReader("\xff\xff\xff")},
+			want: "B\"tW",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToBase91(tt.args.r)); string(got) != tt.want {
+				t.Errorf("ToBase91() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromBase91(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("nX,<:WRT%yc%BQmLGB%gO[yCxT0tJF8j44.2k)8eJ3Zt(PLmfPG3L")},
+			want: "This is only a test of the ToBase91 filter.",
+		},
+		{
+			name: "WhiteSpace",
+			args: args{r: strings.NewReader(">OwJh>}A\nQ;r@@Y?F\n")},
+			want: "Hello, World!",
+		},
+		{
+			name: "Ones",
+			args: args{r: strings.NewReader("B\"tW")},
+			want: "\xff\xff\xff",
+		},
+		{
+			name:    "InvalidCharacter",
+			args:    args{r: strings.NewReader(">OwJh>}A'Q;r@@Y?F")},
+			want:    "Hello,",
+			wantErr: CorruptInputError(8),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromBase91(tt.args.r))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromBase91() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromBase91() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/base91
+
+go 1.24.2
@@ -3,15 +3,90 @@
 // license that can be found in the LICENSE file.
 
 // Package binary defines filters to encode the data as a stream of '0' and '1'
-// characters.  These filters can be connected to other filters via io.Pipes.
+// characters, or of the digits of another power of two radix, such as octal.
+// These filters can be connected to other filters via io.Pipes.
 package binary
 
 import (
 	"errors"
-	"fmt"
 	"io"
+	"strconv"
 )
 
+var (
+	// ErrLength is returned when the encoded data ends part way through the
+	// digits of a byte.
+	ErrLength = errors.New("binary: the data ends part way through a byte")
+	// ErrRadix is returned when the number of bits per digit given to
+	// ToRadix or FromRadix is not from 1 to 5.
+	ErrRadix = errors.New("binary: the bits per digit must be from 1 to 5")
+)
+
+// CorruptInputError is returned when the encoded data holds a character that
+// is not a digit, or the digits of a byte give a value greater than 255.  Its
+// value is the offset of the character, or of the first digit of the byte.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "binary: illegal data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// An Option configures the behaviour of the encoders and decoders.
+type Option func(*options)
+
+type options struct {
+	msbFirst   bool
+	grouping   bool
+	lineLength int
+}
+
+// newOptions applies opts to the default options, in which the digits of
+// each byte are written most significant first if msbFirst is set.
+func newOptions(opts []Option, msbFirst bool) options {
+	o := options{msbFirst: msbFirst}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithMSBFirst writes, and reads, the digits of each byte most significant
+// first, so that 'A' is written as 01000001.  It is the default for ToOctal,
+// FromOctal, ToRadix and FromRadix; ToBinary and FromBinary default to
+// WithLSBFirst.
+func WithMSBFirst() Option {
+	return func(o *options) {
+		o.msbFirst = true
+	}
+}
+
+// WithLSBFirst writes, and reads, the digits of each byte least significant
+// first, so that 'A' is written as 10000010.  It is the default for ToBinary
+// and FromBinary, which keep their original bit order; ToOctal, FromOctal,
+// ToRadix and FromRadix default to WithMSBFirst.
+func WithLSBFirst() Option {
+	return func(o *options) {
+		o.msbFirst = false
+	}
+}
+
+// WithGrouping writes a space between the digits of one byte and the next,
+// which is every 8 bits for ToBinary.
+func WithGrouping() Option {
+	return func(o *options) {
+		o.grouping = true
+	}
+}
+
+// WithLineLength writes a newline after the digits of every n bytes, and at
+// the end of the data.  The default, 0, writes a single line with no
+// newline.
+func WithLineLength(n int) Option {
+	return func(o *options) {
+		o.lineLength = n
+	}
+}
+
 // SetBit - set bit in a byte array
 func SetBit(ary []byte, bit uint) []byte {
 	ary[bit>>3] |= (1 << (bit & 7))
@@ -30,77 +105,17 @@ func GetBit(ary []byte, bit uint) bool {
 }
 
 // ToBinary reads data from r, encodes it as a stream of '0' and '1' characters.
+// The bits of each byte are written least significant first unless the
+// WithMSBFirst option is given.
 // The ToBinary encoded data can be read using the returned PipeReader.
-func ToBinary(r io.Reader) *io.PipeReader {
-	rRdr, rWrtr := io.Pipe()
-
-	go func() {
-		defer rWrtr.Close()
-		for {
-			buf := make([]byte, 1024)
-			cnt, err := r.Read(buf)
-			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
-				break
-			} else if err != nil {
-				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
-			}
-			cnt *= 8
-			for i := 0; i < cnt; i++ {
-				if GetBit(buf, uint(i)) {
-					_, err = fmt.Fprint(rWrtr, "1")
-				} else {
-					_, err = fmt.Fprint(rWrtr, "0")
-				}
-				if err != nil {
-					rWrtr.CloseWithError(fmt.Errorf("error printing to an io.PipeWriter: %w", err))
-				}
-			}
-		}
-	}()
-
-	return rRdr
+func ToBinary(r io.Reader, opts ...Option) *io.PipeReader {
+	return toRadix(r, 1, newOptions(opts, false))
 }
 
-// FromBinary reads data encoded by ToBinary from r, and decodes it.
+// FromBinary reads data encoded by ToBinary from r, and decodes it.  White
+// space, such as that written by the WithGrouping and WithLineLength options,
+// is ignored.
 // The decoded data can be read using the returned PipeReader.
-func FromBinary(r io.Reader) *io.PipeReader {
-	rRdr, rWrtr := io.Pipe()
-	buf := make([]byte, 1024)
-
-	go func() {
-		defer rWrtr.Close()
-		n, err := r.Read(buf)
-		if errors.Is(err, io.EOF) {
-			return
-		} else if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
-			rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
-		}
-		for {
-			outb := make([]byte, 128)
-
-			for i := 0; i < n; i++ {
-				switch string(buf[i]) {
-				case "1":
-					outb = SetBit(outb, uint(i))
-				case "0":
-					outb = ClrBit(outb, uint(i))
-				default:
-					rWrtr.CloseWithError(fmt.Errorf("invalid input to FromBinary"))
-				}
-			}
-
-			_, err = rWrtr.Write(outb[:(n+7)/8])
-			if err != nil {
-				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
-			}
-			n, err = r.Read(buf)
-			if err == io.EOF {
-				break
-			} else if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
-				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
-			}
-		}
-	}()
-
-	return rRdr
+func FromBinary(r io.Reader, opts ...Option) *io.PipeReader {
+	return fromRadix(r, 1, newOptions(opts, false))
 }
@@ -7,6 +7,7 @@
 package binary
 
 import (
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -14,7 +15,8 @@ import (
 
 func TestToBinary(t *testing.T) {
 	type args struct {
-		r io.Reader
+		r    io.Reader
+		opts []Option
 	}
 	tests := []struct {
 		name string
@@ -31,10 +33,20 @@ func TestToBinary(t *testing.T) {
 				"1000011001001110100111100000010001100110100101100011011000101110" +
 				"101001100100111001110100",
 		},
+		{
+			name: "MSBFirst",
+			args: args{r: strings.NewReader("AB"), opts: []Option{WithMSBFirst()}},
+			want: "0100000101000010",
+		},
+		{
+			name: "Grouped",
+			args: args{r: strings.NewReader("ABCDE"), opts: []Option{WithMSBFirst(), WithGrouping(), WithLineLength(2)}},
+			want: "01000001 01000010\n01000011 01000100\n01000101\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got, _ := io.ReadAll(ToBinary(tt.args.r)); strings.Compare(string(got), tt.want) != 0 {
+			if got, _ := io.ReadAll(ToBinary(tt.args.r, tt.args.opts...)); strings.Compare(string(got), tt.want) != 0 {
 				t.Errorf("ToBinary() = %v, want %v", string(got), tt.want)
 			}
 		})
@@ -43,12 +55,14 @@ func TestToBinary(t *testing.T) {
 
 func TestFromBinary(t *testing.T) {
 	type args struct {
-		r io.Reader
+		r    io.Reader
+		opts []Option
 	}
 	tests := []struct {
-		name string
-		args args
-		want string
+		name    string
+		args    args
+		want    string
+		wantErr error
 	}{
 		{
 			name: "TestOne",
@@ -60,10 +74,31 @@ func TestFromBinary(t *testing.T) {
 				"0011011000101110101001100100111001110100")},
 			want: "This is only a test of the FromBinary filter.",
 		},
+		{
+			name: "Grouped",
+			args: args{r: strings.NewReader("01000001 01000010\r\n\t01000011\n"), opts: []Option{WithMSBFirst()}},
+			want: "ABC",
+		},
+		{
+			name:    "BadDigit",
+			args:    args{r: strings.NewReader("10000010 10000210")},
+			want:    "A",
+			wantErr: CorruptInputError(14),
+		},
+		{
+			name:    "Short",
+			args:    args{r: strings.NewReader("10000010 1000")},
+			want:    "A",
+			wantErr: ErrLength,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got, _ := io.ReadAll(FromBinary(tt.args.r)); strings.Compare(string(got), tt.want) != 0 {
+			got, err := io.ReadAll(FromBinary(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromBinary() error = %v, want %v", err, tt.wantErr)
+			}
+			if strings.Compare(string(got), tt.want) != 0 {
 				t.Errorf("FromBinary() = %v, want %v", string(got), tt.want)
 			}
 		})
@@ -0,0 +1,184 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binary
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuv"
+
+// width returns the number of digits of bits bits each needed for a byte.
+func width(bits int) int {
+	return (8 + bits - 1) / bits
+}
+
+// ToOctal reads data from r, encodes it as a stream of octal digits, three
+// for each byte, most significant first unless the WithLSBFirst option is
+// given, so that 'B' is written as 102.  The octal encoded data can be read
+// using the returned PipeReader.
+func ToOctal(r io.Reader, opts ...Option) *io.PipeReader {
+	return ToRadix(r, 3, opts...)
+}
+
+// FromOctal reads data encoded by ToOctal from r, and decodes it.  The
+// decoded data can be read using the returned PipeReader.
+func FromOctal(r io.Reader, opts ...Option) *io.PipeReader {
+	return FromRadix(r, 3, opts...)
+}
+
+// ToRadix reads data from r, encodes each byte as the digits of its value in
+// radix 2**bits, padded with leading zeros to the number needed for 255.  The
+// digits are written most significant first unless the WithLSBFirst option
+// is given.  They are 0-9 followed by the lower case letters, and bits must
+// be from 1 to 5.  The encoded data can be read using the returned
+// PipeReader.
+func ToRadix(r io.Reader, bits int, opts ...Option) *io.PipeReader {
+	return toRadix(r, bits, newOptions(opts, true))
+}
+
+// toRadix is ToRadix with the options already applied.
+func toRadix(r io.Reader, bits int, o options) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	if bits < 1 || bits > 5 {
+		rWrtr.CloseWithError(ErrRadix)
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		br := bufio.NewReader(r)
+		bw := bufio.NewWriter(rWrtr)
+		w, mask := width(bits), byte(1<<bits-1)
+		digit := make([]byte, w)
+		for n := 0; ; n++ {
+			c, err := br.ReadByte()
+			if errors.Is(err, io.EOF) {
+				if o.lineLength > 0 && n > 0 {
+					bw.WriteByte('\n')
+				}
+				break
+			} else if err != nil {
+				bw.Flush()
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			if o.lineLength > 0 && n > 0 && n%o.lineLength == 0 {
+				bw.WriteByte('\n')
+			} else if o.grouping && n > 0 {
+				bw.WriteByte(' ')
+			}
+			for i := range digit {
+				d := digits[c>>(bits*i)&mask]
+				if o.msbFirst {
+					digit[w-1-i] = d
+				} else {
+					digit[i] = d
+				}
+			}
+			if _, err = bw.Write(digit); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+		}
+		if err := bw.Flush(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// FromRadix reads data encoded by ToRadix, with the same bits and digit
+// order, from r, and decodes it.  Upper and lower case letters are accepted,
+// and white space is ignored.  The decoded data can be read using the
+// returned PipeReader.
+func FromRadix(r io.Reader, bits int, opts ...Option) *io.PipeReader {
+	return fromRadix(r, bits, newOptions(opts, true))
+}
+
+// fromRadix is FromRadix with the options already applied.
+func fromRadix(r io.Reader, bits int, o options) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	if bits < 1 || bits > 5 {
+		rWrtr.CloseWithError(ErrRadix)
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		br := bufio.NewReader(r)
+		bw := bufio.NewWriter(rWrtr)
+		w := width(bits)
+		var (
+			val   int   // The value of the digits of the byte read so far.
+			n     int   // The number of digits of the byte read so far.
+			start int64 // The offset of the first digit of the byte.
+		)
+		for offset := int64(0); ; offset++ {
+			c, err := br.ReadByte()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				bw.Flush()
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			switch c {
+			case ' ', '\t', '\r', '\n':
+				continue
+			}
+			d := -1
+			switch {
+			case '0' <= c && c <= '9':
+				d = int(c - '0')
+			case 'a' <= c && c <= 'z':
+				d = int(c-'a') + 10
+			case 'A' <= c && c <= 'Z':
+				d = int(c-'A') + 10
+			}
+			if d < 0 || d >= 1<<bits {
+				bw.Flush()
+				rWrtr.CloseWithError(CorruptInputError(offset))
+				return
+			}
+			if n == 0 {
+				start = offset
+			}
+			if o.msbFirst {
+				val = val<<bits | d
+			} else {
+				val |= d << (bits * n)
+			}
+			if n++; n < w {
+				continue
+			}
+			if val > 0xff {
+				bw.Flush()
+				rWrtr.CloseWithError(CorruptInputError(start))
+				return
+			}
+			if err = bw.WriteByte(byte(val)); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+			val, n = 0, 0
+		}
+		err := bw.Flush()
+		if err != nil {
+			err = fmt.Errorf("error writing to an io.PipeWriter: %w", err)
+		} else if n != 0 {
+			err = ErrLength
+		}
+		if err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr
+}
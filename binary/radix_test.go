@@ -0,0 +1,166 @@
+package binary
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestToRadix(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		bits int
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("A\xff\x08"), bits: 3, opts: []Option{WithGrouping()}},
+			want: "101 377 010",
+		},
+		{
+			name: "Hex",
+			args: args{r: strings.NewReader("A\xfe"), bits: 4},
+			want: "41fe",
+		},
+		{
+			name: "HexLSBFirst",
+			args: args{r: strings.NewReader("A\xfe"), bits: 4, opts: []Option{WithLSBFirst()}},
+			want: "14ef",
+		},
+		{
+			name: "Base32",
+			args: args{r: strings.NewReader("A\xff"), bits: 5, opts: []Option{WithLineLength(1)}},
+			want: "21\n7v\n",
+		},
+		{
+			name: "OctalLSBFirst",
+			args: args{r: strings.NewReader("B\xff\x09"), bits: 3, opts: []Option{WithLSBFirst(), WithGrouping()}},
+			want: "201 773 110",
+		},
+		{
+			name: "Base32LSBFirst",
+			args: args{r: strings.NewReader("A\xff"), bits: 5, opts: []Option{WithLSBFirst()}},
+			want: "12v7",
+		},
+		{
+			name:    "BadRadix",
+			args:    args{r: strings.NewReader("A"), bits: 6},
+			want:    "",
+			wantErr: ErrRadix,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(ToRadix(tt.args.r, tt.args.bits, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToRadix() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToRadix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromRadix(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		bits int
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("101 377\n010\n"), bits: 3},
+			want: "A\xff\x08",
+		},
+		{
+			name: "HexUpperCase",
+			args: args{r: strings.NewReader("41FE"), bits: 4},
+			want: "A\xfe",
+		},
+		{
+			name: "HexLSBFirst",
+			args: args{r: strings.NewReader("14ef"), bits: 4, opts: []Option{WithLSBFirst()}},
+			want: "A\xfe",
+		},
+		{
+			name: "OctalLSBFirst",
+			args: args{r: strings.NewReader("201 773 110"), bits: 3, opts: []Option{WithLSBFirst()}},
+			want: "B\xff\x09",
+		},
+		{
+			name: "Base32LSBFirst",
+			args: args{r: strings.NewReader("12V7"), bits: 5, opts: []Option{WithLSBFirst()}},
+			want: "A\xff",
+		},
+		{
+			name:    "TooLarge",
+			args:    args{r: strings.NewReader("101 401"), bits: 3},
+			want:    "A",
+			wantErr: CorruptInputError(4),
+		},
+		{
+			name:    "BadDigit",
+			args:    args{r: strings.NewReader("108"), bits: 3},
+			want:    "",
+			wantErr: CorruptInputError(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromRadix(tt.args.r, tt.args.bits, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromRadix() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromRadix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToOctal(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "TestOne",
+			want: "102",
+		},
+		{
+			name: "LSBFirst",
+			opts: []Option{WithLSBFirst()},
+			want: "201",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToOctal(strings.NewReader("B"), tt.opts...)); string(got) != tt.want {
+				t.Errorf("ToOctal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOctalRoundTrip(t *testing.T) {
+	const text = "This is only a test of the ToOctal filter."
+	got, err := io.ReadAll(FromOctal(ToOctal(strings.NewReader(text), WithGrouping(), WithLineLength(8))))
+	if err != nil || string(got) != text {
+		t.Errorf("FromOctal(ToOctal()) = %q, %v, want %q", got, err, text)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bundle defines functions to manage bundles of PEM blocks, such as
+// CA bundles and certificate chains.  A bundle can be split into one file
+// per block, merged with other bundles, have duplicate and expired
+// certificates removed, and have its certificates ordered from the leaf to
+// the root.  The headers of each block, any text in front of it, and any
+// text after the last block are preserved by every operation.
+//
+// The pembundle command provides these operations from the command line.
+package bundle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bgallie/filters/pem"
+)
+
+// CertificateType is the PEM type of a certificate.
+const CertificateType = "CERTIFICATE"
+
+// An Item is a single block of a bundle.
+type Item struct {
+	Block pem.Block // The type and headers of the block.
+	Bytes []byte    // The decoded body of the block.
+	Text  []byte    // Any text in front of the block.
+
+	// Trailer holds any text after the block.  Read only sets it on the
+	// last block of a bundle, so the text after the last END line moves
+	// with that block.
+	Trailer []byte
+
+	cert *x509.Certificate
+}
+
+// Certificate returns the parsed certificate held by a CERTIFICATE block.
+func (it *Item) Certificate() (*x509.Certificate, error) {
+	if it.Block.Type != CertificateType {
+		return nil, fmt.Errorf("bundle: a %q block does not hold a certificate", it.Block.Type)
+	}
+	if it.cert == nil {
+		cert, err := x509.ParseCertificate(it.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("bundle: error parsing a certificate: %w", err)
+		}
+		it.cert = cert
+	}
+	return it.cert, nil
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the body of the block.
+func (it *Item) Fingerprint() [sha256.Size]byte {
+	return sha256.Sum256(it.Bytes)
+}
+
+// Read reads all of the blocks of a bundle from r.  Any text after the last
+// block is kept in the Trailer of that block.  The options are passed to
+// pem.NewReader.
+func Read(r io.Reader, opts ...pem.Option) ([]*Item, error) {
+	var items []*Item
+	rdr := pem.NewReader(r, opts...)
+	for entry := range rdr.All() {
+		body, err := io.ReadAll(entry.Body)
+		if err != nil {
+			return nil, fmt.Errorf("bundle: error reading the %q block on line %d: %w", entry.Block.Type, entry.Line, err)
+		}
+		items = append(items, &Item{Block: entry.Block, Bytes: body, Text: entry.Text})
+	}
+	if err := rdr.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		items[len(items)-1].Trailer = rdr.Trailer()
+	}
+	return items, nil
+}
+
+// ReadFile reads all of the blocks of the bundle in the named file.
+func ReadFile(name string, opts ...pem.Option) ([]*Item, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %w", err)
+	}
+	defer f.Close()
+	items, err := Read(f, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return items, nil
+}
+
+// Write writes items to w as a bundle, each block preceded by its text and
+// followed by its trailer.  The options are passed to pem.ToPem.
+func Write(w io.Writer, items []*Item, opts ...pem.Option) error {
+	for _, it := range items {
+		if _, err := w.Write(it.Text); err != nil {
+			return fmt.Errorf("bundle: error writing to an io.Writer: %w", err)
+		}
+		if _, err := io.Copy(w, pem.ToPem(bytes.NewReader(it.Bytes), it.Block, opts...)); err != nil {
+			return fmt.Errorf("bundle: error copying (io.Copy) from a pem.ToPem filter to an io.Writer: %w", err)
+		}
+		if _, err := w.Write(it.Trailer); err != nil {
+			return fmt.Errorf("bundle: error writing to an io.Writer: %w", err)
+		}
+	}
+	return nil
+}
+
+var unsafeChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Split writes each of items to its own file in the directory dir and
+// returns the names of the files.  The files are named after the position
+// and type of the block, i.e. "01-certificate.pem".
+func Split(items []*Item, dir string, opts ...pem.Option) ([]string, error) {
+	var names []string
+	width := len(fmt.Sprint(len(items)))
+	if width < 2 {
+		width = 2
+	}
+	for i, it := range items {
+		kind := strings.Trim(unsafeChars.ReplaceAllString(strings.ToLower(it.Block.Type), "-"), "-")
+		name := filepath.Join(dir, fmt.Sprintf("%0*d-%s.pem", width, i+1, kind))
+		var buf bytes.Buffer
+		if err := Write(&buf, items[i:i+1], opts...); err != nil {
+			return names, err
+		}
+		if err := os.WriteFile(name, buf.Bytes(), 0o644); err != nil {
+			return names, fmt.Errorf("bundle: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// Merge concatenates bundles into a single bundle.
+func Merge(bundles ...[]*Item) []*Item {
+	return slices.Concat(bundles...)
+}
+
+// Dedupe removes the blocks whose type and body duplicate an earlier block,
+// so that certificates with the same fingerprint appear only once.  The
+// first occurrence, with its headers, is kept.
+func Dedupe(items []*Item) []*Item {
+	type key struct {
+		typ string
+		fp  [sha256.Size]byte
+	}
+	seen := make(map[key]bool)
+	var out []*Item
+	for _, it := range items {
+		k := key{it.Block.Type, it.Fingerprint()}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, it)
+	}
+	return out
+}
+
+// DropExpired removes the certificates that are not valid at the time now,
+// either because they have expired or because they are not yet valid.
+// Blocks that are not certificates are kept.
+func DropExpired(items []*Item, now time.Time) ([]*Item, error) {
+	var out []*Item
+	for _, it := range items {
+		if it.Block.Type == CertificateType {
+			cert, err := it.Certificate()
+			if err != nil {
+				return nil, err
+			}
+			if now.After(cert.NotAfter) || now.Before(cert.NotBefore) {
+				continue
+			}
+		}
+		out = append(out, it)
+	}
+	return out, nil
+}
+
+// issuedBy reports if child was issued by parent.
+func issuedBy(child, parent *x509.Certificate) bool {
+	return bytes.Equal(child.RawIssuer, parent.RawSubject) && child.CheckSignatureFrom(parent) == nil
+}
+
+// SortChain orders the certificates from the leaf to the root.  The leaf is
+// the first certificate that did not issue any of the others; each
+// following certificate is the issuer of the one before it.  Certificates
+// that are not part of the chain follow it in their original order, and
+// blocks that are not certificates come last.
+func SortChain(items []*Item) ([]*Item, error) {
+	var certs, others []*Item
+	for _, it := range items {
+		if it.Block.Type != CertificateType {
+			others = append(others, it)
+			continue
+		}
+		if _, err := it.Certificate(); err != nil {
+			return nil, err
+		}
+		certs = append(certs, it)
+	}
+	if len(certs) == 0 {
+		return items, nil
+	}
+	isIssuer := make([]bool, len(certs))
+	for i, parent := range certs {
+		for j, child := range certs {
+			if i != j && issuedBy(child.cert, parent.cert) {
+				isIssuer[i] = true
+				break
+			}
+		}
+	}
+	leaf := slices.Index(isIssuer, false)
+	if leaf < 0 {
+		leaf = 0
+	}
+	used := make([]bool, len(certs))
+	chain := []*Item{certs[leaf]}
+	used[leaf] = true
+	for cur := certs[leaf]; ; {
+		next := -1
+		for i, it := range certs {
+			if !used[i] && issuedBy(cur.cert, it.cert) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+		used[next] = true
+		cur = certs[next]
+		chain = append(chain, cur)
+	}
+	for i, it := range certs {
+		if !used[i] {
+			chain = append(chain, it)
+		}
+	}
+	return append(chain, others...), nil
+}
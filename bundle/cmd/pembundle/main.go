@@ -0,0 +1,109 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Pembundle normalizes bundles of PEM blocks, such as CA bundles.
+//
+// Usage:
+//
+//	pembundle split [-dir directory] [file]
+//	pembundle merge file...
+//	pembundle dedupe [file...]
+//	pembundle sort [file...]
+//	pembundle drop-expired [-at time] [file...]
+//
+// Except for split, the verbs read the named files (or the standard input),
+// merge them, and write the resulting bundle to the standard output.  The
+// verbs may be combined by running pembundle in a pipeline.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bgallie/filters/bundle"
+	"github.com/bgallie/filters/pem"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage:
+	pembundle split [-dir directory] [file]
+	pembundle merge file...
+	pembundle dedupe [file...]
+	pembundle sort [file...]
+	pembundle drop-expired [-at time] [file...]
+`)
+	os.Exit(2)
+}
+
+// readAll reads and merges the bundles in the named files, or the standard
+// input if there are none.
+func readAll(names []string) ([]*bundle.Item, error) {
+	if len(names) == 0 {
+		return bundle.Read(os.Stdin)
+	}
+	var bundles [][]*bundle.Item
+	for _, name := range names {
+		items, err := bundle.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, items)
+	}
+	return bundle.Merge(bundles...), nil
+}
+
+func run(verb string, args []string) error {
+	switch verb {
+	case "split", "merge", "dedupe", "sort", "drop-expired":
+	default:
+		usage()
+	}
+	fs := flag.NewFlagSet(verb, flag.ExitOnError)
+	dir := fs.String("dir", ".", "the directory to write the split files to (split)")
+	at := fs.String("at", "", "the time, in RFC 3339 format, to check expiry at (drop-expired); the default is now")
+	fs.Parse(args)
+	items, err := readAll(fs.Args())
+	if err != nil {
+		return err
+	}
+	width := pem.WithLineWidth(64)
+	switch verb {
+	case "split":
+		names, err := bundle.Split(items, *dir, width)
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return err
+	case "merge":
+	case "dedupe":
+		items = bundle.Dedupe(items)
+	case "sort":
+		if items, err = bundle.SortChain(items); err != nil {
+			return err
+		}
+	case "drop-expired":
+		now := time.Now()
+		if *at != "" {
+			if now, err = time.Parse(time.RFC3339, *at); err != nil {
+				return err
+			}
+		}
+		if items, err = bundle.DropExpired(items, now); err != nil {
+			return err
+		}
+	}
+	return bundle.Write(os.Stdout, items, width)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	if err := run(os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "pembundle:", err)
+		os.Exit(1)
+	}
+}
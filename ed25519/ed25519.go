@@ -0,0 +1,212 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ed25519 defines filters to sign a stream of data and to verify the
+// signature using Ed25519.  The signing filters pass the data through while
+// computing its SHA-512 hash, and sign the hash using Ed25519ph (RFC 8032).
+// The signature is either written separately (detached) or appended to the
+// data as a trailer (attached).  The verifying filters withhold the final
+// part of the data until the signature has been verified.  These filters can
+// be connected to other filters via io.Pipes.
+package ed25519
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/bgallie/filters/pem"
+)
+
+const (
+	// SignatureSize is the size of an Ed25519 signature.
+	SignatureSize = ed25519.SignatureSize
+	// SignatureType is the PEM type used for signatures.
+	SignatureType = "ED25519 SIGNATURE"
+)
+
+// ErrVerification is returned when the signature does not match the data.
+var ErrVerification = errors.New("ed25519: signature verification failed")
+
+var options = &ed25519.Options{Hash: crypto.SHA512}
+
+// signHash signs the SHA-512 hash h using key.
+func signHash(key ed25519.PrivateKey, h hash.Hash) ([]byte, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("ed25519: bad private key length: %d", len(key))
+	}
+	return key.Sign(nil, h.Sum(nil), options)
+}
+
+// sign copies the data from r to w while hashing it and returns the
+// signature of the data.
+func sign(r io.Reader, w io.Writer, key ed25519.PrivateKey) ([]byte, error) {
+	h := sha512.New()
+	if _, err := io.Copy(w, io.TeeReader(r, h)); err != nil {
+		return nil, fmt.Errorf("error copying (io.Copy) from an io.Reader to an io.PipeWriter: %w", err)
+	}
+	return signHash(key, h)
+}
+
+// SignDetached reads data from r and passes it through unchanged while
+// signing it with key.  The data can be read using the returned PipeReader.
+// When all of the data has been read, the signature is written to sig before
+// the PipeReader returns io.EOF.
+func SignDetached(r io.Reader, key ed25519.PrivateKey, sig io.Writer) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		s, err := sign(r, rWrtr, key)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		if _, err = sig.Write(s); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing the signature to an io.Writer: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// SignAttached reads data from r and passes it through unchanged while
+// signing it with key.  The signature is appended to the data as a
+// SignatureSize byte trailer.  The signed data can be read using the
+// returned PipeReader.
+func SignAttached(r io.Reader, key ed25519.PrivateKey) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		s, err := sign(r, rWrtr, key)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		if _, err = rWrtr.Write(s); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing the signature to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// verify copies the data from r to w while hashing it.  The last chunk of
+// data read from r is held back until the signature has been verified.  If
+// sig is nil, the signature is taken from the trailer of the data.
+func verify(r io.Reader, w *io.PipeWriter, pub ed25519.PublicKey, sig []byte) {
+	if len(pub) != ed25519.PublicKeySize {
+		w.CloseWithError(fmt.Errorf("ed25519: bad public key length: %d", len(pub)))
+		return
+	}
+	attached := sig == nil
+	h := sha512.New()
+	buf := make([]byte, 32*1024)
+	var pending []byte
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			pending = append(pending, buf[:n]...)
+			keep := n
+			if attached {
+				keep += SignatureSize
+			}
+			if len(pending) > keep {
+				out := pending[:len(pending)-keep]
+				h.Write(out)
+				if _, err := w.Write(out); err != nil {
+					w.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+					return
+				}
+				pending = append(pending[:0], pending[len(pending)-keep:]...)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			w.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+			return
+		}
+	}
+	if attached {
+		if len(pending) < SignatureSize {
+			w.CloseWithError(fmt.Errorf("the signature trailer is missing: %w", ErrVerification))
+			return
+		}
+		pending, sig = pending[:len(pending)-SignatureSize], pending[len(pending)-SignatureSize:]
+	}
+	h.Write(pending)
+	if ed25519.VerifyWithOptions(pub, h.Sum(nil), sig, options) != nil {
+		w.CloseWithError(ErrVerification)
+		return
+	}
+	if _, err := w.Write(pending); err != nil {
+		w.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+	}
+}
+
+// VerifyDetached reads data from r and passes it through unchanged while
+// verifying it against the detached signature sig using pub.  The data can
+// be read using the returned PipeReader.  The final part of the data is
+// withheld until the signature has been verified; if it does not match, the
+// final read returns ErrVerification.
+func VerifyDetached(r io.Reader, pub ed25519.PublicKey, sig []byte) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		if len(sig) != SignatureSize {
+			rWrtr.CloseWithError(fmt.Errorf("bad signature length %d: %w", len(sig), ErrVerification))
+			return
+		}
+		verify(r, rWrtr, pub, sig)
+	}()
+
+	return rRdr
+}
+
+// VerifyAttached reads data signed by SignAttached from r and verifies the
+// signature trailer using pub.  The data, without the trailer, can be read
+// using the returned PipeReader.  The final part of the data is withheld
+// until the signature has been verified; if it does not match, the final
+// read returns ErrVerification.
+func VerifyAttached(r io.Reader, pub ed25519.PublicKey) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		verify(r, rWrtr, pub, nil)
+	}()
+
+	return rRdr
+}
+
+// SignatureToPem encodes sig in a PEM block of type SignatureType.  The PEM
+// encoded signature can be read using the returned PipeReader.
+func SignatureToPem(sig []byte) *io.PipeReader {
+	return pem.ToPem(bytes.NewReader(sig), pem.Block{Type: SignatureType})
+}
+
+// SignatureFromPem reads a PEM encoded signature written by SignatureToPem
+// from r.
+func SignatureFromPem(r io.Reader) ([]byte, error) {
+	rdr, blk := pem.FromPem(r)
+	sig, err := io.ReadAll(rdr)
+	if err != nil {
+		return nil, fmt.Errorf("ed25519: error reading a PEM block: %w", err)
+	}
+	if blk.Type != SignatureType {
+		return nil, fmt.Errorf("ed25519: unexpected PEM type %q, want %q", blk.Type, SignatureType)
+	}
+	if len(sig) != SignatureSize {
+		return nil, fmt.Errorf("ed25519: bad signature length: %d", len(sig))
+	}
+	return sig, nil
+}
@@ -0,0 +1,82 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDetached(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	other, _, _ := ed25519.GenerateKey(rand.Reader)
+	want := strings.Repeat("This is only a test.  ", 5000)
+	var sig bytes.Buffer
+	got, err := io.ReadAll(SignDetached(strings.NewReader(want), priv, &sig))
+	if err != nil || string(got) != want {
+		t.Fatalf("SignDetached() error = %v, returned %d bytes", err, len(got))
+	}
+	pemSig, err := SignatureFromPem(SignatureToPem(sig.Bytes()))
+	if err != nil || !bytes.Equal(pemSig, sig.Bytes()) {
+		t.Fatalf("SignatureFromPem() = %x, %v, want %x", pemSig, err, sig.Bytes())
+	}
+	tests := []struct {
+		name    string
+		data    string
+		pub     ed25519.PublicKey
+		wantErr error
+	}{
+		{name: "Valid", data: want, pub: pub},
+		{name: "WrongKey", data: want, pub: other, wantErr: ErrVerification},
+		{name: "Modified", data: want + ".", pub: pub, wantErr: ErrVerification},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(VerifyDetached(strings.NewReader(tt.data), tt.pub, pemSig))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyDetached() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && string(got) != tt.data {
+				t.Errorf("VerifyDetached() returned %d bytes, want %d", len(got), len(tt.data))
+			}
+			if err != nil && len(got) >= len(tt.data) {
+				t.Errorf("VerifyDetached() released all %d bytes of unverified data", len(got))
+			}
+		})
+	}
+}
+
+func TestAttached(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	want := strings.Repeat("This is only a test.  ", 5000)
+	signed, err := io.ReadAll(SignAttached(strings.NewReader(want), priv))
+	if err != nil || len(signed) != len(want)+SignatureSize {
+		t.Fatalf("SignAttached() error = %v, returned %d bytes", err, len(signed))
+	}
+	tampered := bytes.Clone(signed)
+	tampered[10] ^= 0x01
+	tests := []struct {
+		name    string
+		in      []byte
+		want    string
+		wantErr error
+	}{
+		{name: "Valid", in: signed, want: want},
+		{name: "Tampered", in: tampered, wantErr: ErrVerification},
+		{name: "Empty", in: []byte{}, wantErr: ErrVerification},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(VerifyAttached(bytes.NewReader(tt.in), pub))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyAttached() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && string(got) != tt.want {
+				t.Errorf("VerifyAttached() returned %d bytes, want %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
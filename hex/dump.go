@@ -0,0 +1,323 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hex
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A DumpStyle selects the layout of a hex dump.
+type DumpStyle int
+
+const (
+	// Canonical is the layout of hexdump -C: an offset, the bytes in
+	// groups of 8, the printable characters between '|'s, and a final line
+	// holding the length of the data.
+	Canonical DumpStyle = iota
+	// XXD is the layout of xxd: an offset followed by ':', the bytes in
+	// groups of 2 with no space within a group, and the printable
+	// characters.
+	XXD
+)
+
+// WithStyle selects the layout written by ToDump.  The default is Canonical.
+func WithStyle(s DumpStyle) Option {
+	return func(o *options) {
+		o.style = s
+	}
+}
+
+// WithColumns sets the number of bytes on each line written by ToDump, or
+// in each data record written by ToIntelHex and ToSRecord.  The default is
+// 16.
+func WithColumns(n int) Option {
+	return func(o *options) {
+		o.columns = n
+	}
+}
+
+// WithGroupSize sets the number of bytes in each group written by ToDump.
+// The default is 8 for Canonical and 2 for XXD.
+func WithGroupSize(n int) Option {
+	return func(o *options) {
+		o.group = n
+	}
+}
+
+// WithUpperCase makes ToDump write upper case hexadecimal digits.
+func WithUpperCase() Option {
+	return func(o *options) {
+		o.upper = true
+	}
+}
+
+// WithPlain makes FromDump read lines without offsets or printable
+// characters, as xxd -r -p does.
+func WithPlain() Option {
+	return func(o *options) {
+		o.plain = true
+	}
+}
+
+// dumpLine returns the line of a dump of b, which is at offset off.
+func (o options) dumpLine(b []byte, off int64) []byte {
+	digits := "0123456789abcdef"
+	if o.upper {
+		digits = "0123456789ABCDEF"
+	}
+	var line []byte
+	if o.style == XXD {
+		line = fmt.Appendf(line, "%08x: ", off)
+	} else {
+		line = fmt.Appendf(line, "%08x  ", off)
+	}
+	for i := 0; i < o.columns; i++ {
+		if o.style == XXD {
+			if i < len(b) {
+				line = append(line, digits[b[i]>>4], digits[b[i]&0x0f])
+			} else {
+				line = append(line, ' ', ' ')
+			}
+			if (i+1)%o.group == 0 || i == o.columns-1 {
+				line = append(line, ' ')
+			}
+			continue
+		}
+		if i > 0 && i%o.group == 0 {
+			line = append(line, ' ')
+		}
+		if i < len(b) {
+			line = append(line, digits[b[i]>>4], digits[b[i]&0x0f], ' ')
+		} else {
+			line = append(line, ' ', ' ', ' ')
+		}
+	}
+	line = append(line, ' ')
+	if o.style == Canonical {
+		line = append(line, '|')
+	}
+	for _, c := range b {
+		if c < ' ' || c > '~' {
+			c = '.'
+		}
+		line = append(line, c)
+	}
+	if o.style == Canonical {
+		line = append(line, '|')
+	}
+	return append(line, '\n')
+}
+
+// ToDump reads data from r, formats it as a hex dump.  The hex dump can be
+// read using the returned PipeReader.
+func ToDump(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+	if o.columns == 0 {
+		o.columns = 16
+	}
+	if o.group == 0 {
+		o.group = 8
+		if o.style == XXD {
+			o.group = 2
+		}
+	}
+	if o.columns < 0 || o.group < 0 {
+		rWrtr.CloseWithError(fmt.Errorf("hex: invalid columns (%d) or group size (%d)", o.columns, o.group))
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		buf := make([]byte, o.columns)
+		var off int64
+		for {
+			n, err := io.ReadFull(r, buf)
+			if n > 0 {
+				if _, werr := rWrtr.Write(o.dumpLine(buf[:n], off)); werr != nil {
+					rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", werr))
+					return
+				}
+				off += int64(n)
+			}
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			} else if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+		}
+		if o.style == Canonical && off > 0 {
+			if _, err := fmt.Fprintf(rWrtr, "%08x\n", off); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+			}
+		}
+	}()
+
+	return rRdr
+}
+
+// parseBytes returns the bytes given by s, a list of hexadecimal numbers,
+// each of an even number of digits and with an optional "0x" prefix,
+// separated by white space, ':', ',' or '-'.
+func parseBytes(s string) ([]byte, error) {
+	var data []byte
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ':' || r == ',' || r == '-'
+	})
+	for _, f := range fields {
+		if len(f) > 2 && (f[:2] == "0x" || f[:2] == "0X") {
+			f = f[2:]
+		}
+		b, err := hex.DecodeString(f)
+		if err != nil {
+			return nil, fmt.Errorf("bad hexadecimal number %q", f)
+		}
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
+// parseOffset returns the offset at the start of line, the rest of the
+// line, and whether the offset was followed by ':', as in xxd's layout.
+func parseOffset(line string) (int64, string, bool, error) {
+	line = strings.TrimLeft(line, " \t")
+	tok, rest := line, ""
+	end := strings.IndexAny(line, ": \t")
+	if end >= 0 {
+		tok, rest = line[:end], line[end+1:]
+	}
+	if len(tok) > 2 && (tok[:2] == "0x" || tok[:2] == "0X") {
+		tok = tok[2:]
+	}
+	off, err := strconv.ParseInt(tok, 16, 64)
+	if err != nil {
+		return 0, "", false, fmt.Errorf("bad offset %q", tok)
+	}
+	return off, rest, end >= 0 && line[end] == ':', nil
+}
+
+// maxGap is the largest number of bytes that FromDump fills in before an
+// offset, so that a corrupt or hostile offset cannot make it write without
+// end.
+const maxGap = 16 << 20
+
+// FromDump reads a hex dump from r, and parses it back into the data, as
+// xxd -r does.  Each line starts with the offset of its bytes, which is
+// followed by ':' in xxd's layout, and may end with the printable
+// characters.  Missing data before an offset is filled with zeros, and a
+// "*" line, which hexdump uses to replace repeated lines, is filled by
+// repeating the line before it; no more than 16MiB may be filled in before a
+// line.  Upper and lower case digits are accepted, and bytes may be
+// separated by white space, ':', ',' or '-' and have a "0x" prefix.  The data
+// can be read using the returned PipeReader.
+func FromDump(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		var (
+			br      = bufio.NewReader(r)
+			bw      = bufio.NewWriter(rWrtr)
+			written int64
+			prev    []byte // The bytes of the previous line.
+			repeat  bool   // If the previous line was "*".
+		)
+		fail := func(lineNo int, err error) {
+			bw.Flush()
+			rWrtr.CloseWithError(fmt.Errorf("%w: line %d: %v", ErrFormat, lineNo, err))
+		}
+		for lineNo := 1; ; lineNo++ {
+			line, err := br.ReadString('\n')
+			if errors.Is(err, io.EOF) && line == "" {
+				break
+			} else if err != nil && !errors.Is(err, io.EOF) {
+				bw.Flush()
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if o.plain {
+				data, err := parseBytes(line)
+				if err != nil {
+					fail(lineNo, err)
+					return
+				}
+				if _, err = bw.Write(data); err != nil {
+					rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+					return
+				}
+				continue
+			}
+			if strings.TrimSpace(line) == "*" {
+				repeat = true
+				continue
+			}
+			off, rest, xxd, err := parseOffset(line)
+			if err != nil {
+				fail(lineNo, err)
+				return
+			}
+			// Remove the printable characters.
+			if xxd {
+				if i := strings.Index(rest, "  "); i >= 0 {
+					rest = rest[:i]
+				}
+			} else if i := strings.Index(rest, "  |"); i >= 0 {
+				rest = rest[:i]
+			}
+			if off < written {
+				fail(lineNo, fmt.Errorf("offset %#x is before the end of the data, %#x", off, written))
+				return
+			}
+			if off-written > maxGap {
+				fail(lineNo, fmt.Errorf("offset %#x is more than %d bytes after the end of the data, %#x", off, maxGap, written))
+				return
+			}
+			var werr error
+			for repeat && len(prev) > 0 && written < off && werr == nil {
+				n := min(int64(len(prev)), off-written)
+				_, werr = bw.Write(prev[:n])
+				written += n
+			}
+			repeat = false
+			for ; written < off && werr == nil; written++ {
+				werr = bw.WriteByte(0)
+			}
+			if werr != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", werr))
+				return
+			}
+			data, err := parseBytes(rest)
+			if err != nil {
+				fail(lineNo, err)
+				return
+			}
+			if _, err = bw.Write(data); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+			written += int64(len(data))
+			if len(data) > 0 {
+				prev = data
+			}
+		}
+		if err := bw.Flush(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
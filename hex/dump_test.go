@@ -0,0 +1,133 @@
+package hex
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const (
+	testDumpText  = "This is only a test of the ToDump filter.\n\x00\x01\xff"
+	testCanonical = "00000000  54 68 69 73 20 69 73 20  6f 6e 6c 79 20 61 20 74  |This is only a t|\n" +
+		"00000010  65 73 74 20 6f 66 20 74  68 65 20 54 6f 44 75 6d  |est of the ToDum|\n" +
+		"00000020  70 20 66 69 6c 74 65 72  2e 0a 00 01 ff           |p filter.....|\n" +
+		"0000002d\n"
+	testXXD = "00000000: 5468 6973 2069 7320 6f6e 6c79 2061 2074  This is only a t\n" +
+		"00000010: 6573 7420 6f66 2074 6865 2054 6f44 756d  est of the ToDum\n" +
+		"00000020: 7020 6669 6c74 6572 2e0a 0001 ff         p filter.....\n"
+)
+
+func TestToDump(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testDumpText)},
+			want: testCanonical,
+		},
+		{
+			name: "XXD",
+			args: args{r: strings.NewReader(testDumpText), opts: []Option{WithStyle(XXD)}},
+			want: testXXD,
+		},
+		{
+			name: "ColumnsAndGroups",
+			args: args{r: strings.NewReader(testDumpText[:12]), opts: []Option{WithStyle(XXD), WithColumns(8), WithGroupSize(1), WithUpperCase()}},
+			want: "00000000: 54 68 69 73 20 69 73 20  This is \n" +
+				"00000008: 6F 6E 6C 79              only\n",
+		},
+		{
+			name: "Empty",
+			args: args{r: strings.NewReader("")},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToDump(tt.args.r, tt.args.opts...)); string(got) != tt.want {
+				t.Errorf("ToDump() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromDump(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testCanonical)},
+			want: testDumpText,
+		},
+		{
+			name: "XXD",
+			args: args{r: strings.NewReader(testXXD)},
+			want: testDumpText,
+		},
+		{
+			name: "Squeezed",
+			args: args{r: strings.NewReader("00000000  41 41 41 41  |AAAA|\n*\n0000000c  42  |B|\n0000000d\n")},
+			want: "AAAAAAAAAAAAB",
+		},
+		{
+			name: "Gap",
+			args: args{r: strings.NewReader("0: 4142\n0x6: 43\n")},
+			want: "AB\x00\x00\x00\x00C",
+		},
+		{
+			name: "Separators",
+			args: args{r: strings.NewReader("00000000: 0x41, 0X42:43-44 4546\r\n")},
+			want: "ABCDEF",
+		},
+		{
+			name: "Plain",
+			args: args{r: strings.NewReader("54686973 2069\n73:20:6F:6E, 0x6c\n"), opts: []Option{WithPlain()}},
+			want: "This is onl",
+		},
+		{
+			name:    "OddDigits",
+			args:    args{r: strings.NewReader("00000000: 4142 434\n")},
+			want:    "",
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "HugeGap",
+			args:    args{r: strings.NewReader("7fffffffffffffff: 41\n")},
+			want:    "",
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "Backwards",
+			args:    args{r: strings.NewReader("00000010: 4142\n00000000: 4344\n")},
+			want:    strings.Repeat("\x00", 16) + "AB",
+			wantErr: ErrFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromDump(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromDump() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromDump() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
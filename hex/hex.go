@@ -3,16 +3,51 @@
 // license that can be found in the LICENSE file.
 
 // Package hex defines filters to encode/decode data to/from a stream of
-// hexadecimal characters.  These filters can be connected to other filters
-// via io.Pipes.
+// hexadecimal characters, to/from hex dumps in the styles of hexdump -C and
+// xxd, and to/from the Intel HEX and Motorola S-record formats used to load
+// firmware.  These filters can be connected to other filters via io.Pipes.
 package hex
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 )
 
+var (
+	// ErrFormat is returned when hexadecimal data that is read is
+	// malformed.
+	ErrFormat = errors.New("hex: malformed data")
+	// ErrChecksum is returned when the checksum of an Intel HEX or
+	// S-record record does not match its contents.
+	ErrChecksum = errors.New("hex: record checksum mismatch")
+)
+
+// An Option configures the behaviour of the filters.  Each option
+// documents the filters that use it.
+type Option func(*options)
+
+type options struct {
+	style   DumpStyle
+	columns int
+	group   int
+	upper   bool
+	plain   bool
+	base    uint32
+	baseSet bool
+	fill    byte
+	fillSet bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // ToHex reads data from r, encodes it using hex encoder.  The encoded
 // data can be read using the returned PipeReader.
 func ToHex(r io.Reader) *io.PipeReader {
@@ -0,0 +1,412 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hex
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Intel HEX record types.
+const (
+	ihexData                = 0x00
+	ihexEOF                 = 0x01
+	ihexExtendedSegmentAddr = 0x02
+	ihexStartSegmentAddr    = 0x03
+	ihexExtendedLinearAddr  = 0x04
+	ihexStartLinearAddr     = 0x05
+)
+
+const (
+	// maxAddress is the highest address that can be given in a record.
+	maxAddress = 1<<32 - 1
+	// defaultFill is the default byte written for gaps between records.
+	defaultFill byte = 0xff
+)
+
+// WithBaseAddress sets the address of the first byte of the data.
+// ToIntelHex and ToSRecord place the data at the address; FromIntelHex and
+// FromSRecord start their output at it, instead of at the address of the
+// first data record.  The default is 0 for the encoders.
+func WithBaseAddress(addr uint32) Option {
+	return func(o *options) {
+		o.base, o.baseSet = addr, true
+	}
+}
+
+// WithFill sets the byte that FromIntelHex and FromSRecord write for the
+// addresses between records that hold no data.  The default is 0xff, the
+// value of erased flash memory.
+func WithFill(b byte) Option {
+	return func(o *options) {
+		o.fill, o.fillSet = b, true
+	}
+}
+
+func newRecordOptions(opts []Option) options {
+	o := newOptions(opts)
+	if o.columns <= 0 {
+		o.columns = 16
+	}
+	if !o.fillSet {
+		o.fill = defaultFill
+	}
+	return o
+}
+
+// readBlocks reads data from r in blocks of up to size bytes, and calls fn
+// with the address of each, starting at base.  A block never crosses a
+// multiple of split.
+func readBlocks(r io.Reader, base uint32, size int, split uint64, fn func(addr uint64, data []byte) error) error {
+	buf := make([]byte, size)
+	addr := uint64(base)
+	for {
+		n, err := io.ReadFull(r, buf)
+		for data := buf[:n]; len(data) > 0; {
+			if addr+uint64(len(data))-1 > maxAddress {
+				return fmt.Errorf("%w: the data extends beyond address %#x", ErrFormat, uint64(maxAddress))
+			}
+			m := min(uint64(len(data)), split-addr%split)
+			if ferr := fn(addr, data[:m]); ferr != nil {
+				return ferr
+			}
+			addr += m
+			data = data[m:]
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading from an io.Reader: %w", err)
+		}
+	}
+}
+
+// ihexRecord writes an Intel HEX record.
+func ihexRecord(w *bufio.Writer, typ byte, addr uint16, data []byte) error {
+	rec := append([]byte{byte(len(data)), byte(addr >> 8), byte(addr), typ}, data...)
+	var sum byte
+	for _, b := range rec {
+		sum += b
+	}
+	rec = append(rec, -sum)
+	w.WriteByte(':')
+	w.WriteString(strings.ToUpper(hex.EncodeToString(rec)))
+	return w.WriteByte('\n')
+}
+
+// ToIntelHex reads data from r, encodes it as Intel HEX data records,
+// placed at the base address, followed by an end of file record.  Extended
+// linear address records are written when the data reaches a new 64KiB
+// segment.  The Intel HEX records can be read using the returned PipeReader.
+func ToIntelHex(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newRecordOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		bw := bufio.NewWriter(rWrtr)
+		var upper uint64
+		err := readBlocks(r, o.base, min(o.columns, 255), 1<<16, func(addr uint64, data []byte) error {
+			if addr>>16 != upper {
+				upper = addr >> 16
+				ihexRecord(bw, ihexExtendedLinearAddr, 0, []byte{byte(upper >> 8), byte(upper)})
+			}
+			return ihexRecord(bw, ihexData, uint16(addr), data)
+		})
+		if err == nil {
+			err = ihexRecord(bw, ihexEOF, 0, nil)
+		}
+		if ferr := bw.Flush(); err == nil && ferr != nil {
+			err = fmt.Errorf("error writing to an io.PipeWriter: %w", ferr)
+		}
+		if err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr
+}
+
+// srecAddrLen returns the number of address bytes used by S-record type
+// typ, which must be a data or termination record.
+func srecAddrLen(typ byte) int {
+	switch typ {
+	case '1', '9':
+		return 2
+	case '2', '8':
+		return 3
+	}
+	return 4
+}
+
+// srecRecord writes an S-record.
+func srecRecord(w *bufio.Writer, typ byte, addrLen int, addr uint64, data []byte) error {
+	rec := []byte{byte(addrLen + len(data) + 1)}
+	for i := addrLen - 1; i >= 0; i-- {
+		rec = append(rec, byte(addr>>(8*i)))
+	}
+	rec = append(rec, data...)
+	var sum byte
+	for _, b := range rec {
+		sum += b
+	}
+	rec = append(rec, ^sum)
+	w.WriteByte('S')
+	w.WriteByte(typ)
+	w.WriteString(strings.ToUpper(hex.EncodeToString(rec)))
+	return w.WriteByte('\n')
+}
+
+// ToSRecord reads data from r, encodes it as Motorola S-records: a header
+// record, data records placed at the base address, a count record, and a
+// termination record giving the base address as the start address.  The
+// data records are S1 records while their addresses fit in 16 bits, then S2
+// records while they fit in 24 bits, then S3 records; the termination
+// record matches the last data record.  The S-records can be read using the
+// returned PipeReader.
+func ToSRecord(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newRecordOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		bw := bufio.NewWriter(rWrtr)
+		srecRecord(bw, '0', 2, 0, nil)
+		typ := byte('1')
+		count := 0
+		err := readBlocks(r, o.base, min(o.columns, 250), 1<<32, func(addr uint64, data []byte) error {
+			end := addr + uint64(len(data)) - 1
+			if end > 0xffffff {
+				typ = '3'
+			} else if end > 0xffff && typ == '1' {
+				typ = '2'
+			}
+			count++
+			return srecRecord(bw, typ, srecAddrLen(typ), addr, data)
+		})
+		if err == nil {
+			if count <= 0xffff {
+				srecRecord(bw, '5', 2, uint64(count), nil)
+			} else {
+				srecRecord(bw, '6', 3, uint64(count), nil)
+			}
+			term := byte('9' - (typ - '1'))
+			err = srecRecord(bw, term, srecAddrLen(term), uint64(o.base), nil)
+		}
+		if ferr := bw.Flush(); err == nil && ferr != nil {
+			err = fmt.Errorf("error writing to an io.PipeWriter: %w", ferr)
+		}
+		if err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr
+}
+
+// flattener writes the data of records to w as a contiguous stream,
+// filling the gaps between them.  As for FromDump, a gap may be no more than
+// maxGap bytes.
+type flattener struct {
+	w       *bufio.Writer
+	o       options
+	next    uint64 // The address of the next byte to write.
+	started bool
+}
+
+// write writes data, the contents of the record on line lineNo, at addr.
+func (f *flattener) write(addr uint64, data []byte, lineNo int) error {
+	if !f.started {
+		f.started = true
+		f.next = addr
+		if f.o.baseSet {
+			f.next = uint64(f.o.base)
+		}
+	}
+	if addr < f.next {
+		return fmt.Errorf("%w: line %d: data at address %#x is out of order, or before the base address", ErrFormat, lineNo, addr)
+	}
+	if addr-f.next > maxGap {
+		return fmt.Errorf("%w: line %d: data at address %#x is more than %d bytes after the end of the data, %#x", ErrFormat, lineNo, addr, maxGap, f.next)
+	}
+	for ; f.next < addr; f.next++ {
+		if err := f.w.WriteByte(f.o.fill); err != nil {
+			return fmt.Errorf("error writing to an io.PipeWriter: %w", err)
+		}
+	}
+	f.next += uint64(len(data))
+	if _, err := f.w.Write(data); err != nil {
+		return fmt.Errorf("error writing to an io.PipeWriter: %w", err)
+	}
+	return nil
+}
+
+// readRecords reads the records, one per line, from r, and calls fn with
+// the bytes given by the hexadecimal digits that follow the first skip
+// characters of each, the line, and the line number.  Each record must
+// start with start, and blank lines are skipped.  Reading stops when fn
+// returns done.
+func readRecords(r io.Reader, start byte, skip int, fn func(rec []byte, line string, lineNo int) (done bool, err error)) error {
+	br := bufio.NewReader(r)
+	for lineNo := 1; ; lineNo++ {
+		line, err := br.ReadString('\n')
+		if errors.Is(err, io.EOF) && line == "" {
+			return fmt.Errorf("%w: the data ends before the end record", ErrFormat)
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("error reading from an io.Reader: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if len(line) < skip || line[0] != start {
+			return fmt.Errorf("%w: line %d: the record does not start with %q", ErrFormat, lineNo, start)
+		}
+		rec, herr := hex.DecodeString(line[skip:])
+		if herr != nil {
+			return fmt.Errorf("%w: line %d: %v", ErrFormat, lineNo, herr)
+		}
+		if done, ferr := fn(rec, line, lineNo); ferr != nil || done {
+			return ferr
+		}
+	}
+}
+
+// FromIntelHex reads Intel HEX records from r, checks their checksums, and
+// writes the data of the data records, at their addresses, as a contiguous
+// stream.  Extended segment and extended linear address records are
+// supported, start address records are ignored, and reading stops at the
+// end of file record.  The records must be in ascending address order, with
+// gaps of no more than 16MiB.  The data can be read using the returned
+// PipeReader.
+func FromIntelHex(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newRecordOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		bw := bufio.NewWriter(rWrtr)
+		f := &flattener{w: bw, o: o}
+		var upper uint64
+		err := readRecords(r, ':', 1, func(rec []byte, _ string, lineNo int) (bool, error) {
+			if len(rec) < 5 || int(rec[0]) != len(rec)-5 {
+				return false, fmt.Errorf("%w: line %d: the record length does not match its byte count", ErrFormat, lineNo)
+			}
+			var sum byte
+			for _, b := range rec {
+				sum += b
+			}
+			if sum != 0 {
+				return false, fmt.Errorf("%w: line %d", ErrChecksum, lineNo)
+			}
+			addr, data := uint64(rec[1])<<8|uint64(rec[2]), rec[4:len(rec)-1]
+			switch rec[3] {
+			case ihexData:
+				if err := f.write(upper+addr, data, lineNo); err != nil {
+					return false, err
+				}
+			case ihexEOF:
+				return true, nil
+			case ihexExtendedSegmentAddr, ihexExtendedLinearAddr:
+				if len(data) != 2 {
+					return false, fmt.Errorf("%w: line %d: an address record must hold 2 bytes", ErrFormat, lineNo)
+				}
+				upper = uint64(data[0])<<8 | uint64(data[1])
+				if rec[3] == ihexExtendedSegmentAddr {
+					upper <<= 4
+				} else {
+					upper <<= 16
+				}
+			case ihexStartSegmentAddr, ihexStartLinearAddr:
+			default:
+				return false, fmt.Errorf("%w: line %d: unknown record type %#02x", ErrFormat, lineNo, rec[3])
+			}
+			return false, nil
+		})
+		if ferr := bw.Flush(); err == nil && ferr != nil {
+			err = fmt.Errorf("error writing to an io.PipeWriter: %w", ferr)
+		}
+		if err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr
+}
+
+// FromSRecord reads Motorola S-records from r, checks their checksums, and
+// writes the data of the S1, S2 and S3 records, at their addresses, as a
+// contiguous stream.  Header records are ignored, a count record must match
+// the number of data records, and reading stops at the termination record.
+// The records must be in ascending address order, with gaps of no more than
+// 16MiB.  The data can be read using the returned PipeReader.
+func FromSRecord(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newRecordOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		bw := bufio.NewWriter(rWrtr)
+		f := &flattener{w: bw, o: o}
+		count := 0
+		err := readRecords(r, 'S', 2, func(rec []byte, line string, lineNo int) (bool, error) {
+			typ := line[1]
+			if len(rec) < 1 || int(rec[0]) != len(rec)-1 {
+				return false, fmt.Errorf("%w: line %d: the record length does not match its byte count", ErrFormat, lineNo)
+			}
+			var sum byte
+			for _, b := range rec {
+				sum += b
+			}
+			if sum != 0xff {
+				return false, fmt.Errorf("%w: line %d", ErrChecksum, lineNo)
+			}
+			addrLen := 2
+			switch typ {
+			case '0', '5':
+			case '6':
+				addrLen = 3
+			case '1', '2', '3', '7', '8', '9':
+				addrLen = srecAddrLen(typ)
+			default:
+				return false, fmt.Errorf("%w: line %d: unknown record type S%c", ErrFormat, lineNo, typ)
+			}
+			if len(rec) < 2+addrLen {
+				return false, fmt.Errorf("%w: line %d: the record is too short", ErrFormat, lineNo)
+			}
+			var addr uint64
+			for _, b := range rec[1 : 1+addrLen] {
+				addr = addr<<8 | uint64(b)
+			}
+			data := rec[1+addrLen : len(rec)-1]
+			switch typ {
+			case '1', '2', '3':
+				count++
+				if err := f.write(addr, data, lineNo); err != nil {
+					return false, err
+				}
+			case '5', '6':
+				if addr != uint64(count) {
+					return false, fmt.Errorf("%w: line %d: the count record gives %d data records, but there are %d", ErrFormat, lineNo, addr, count)
+				}
+			case '7', '8', '9':
+				return true, nil
+			}
+			return false, nil
+		})
+		if ferr := bw.Flush(); err == nil && ferr != nil {
+			err = fmt.Errorf("error writing to an io.PipeWriter: %w", ferr)
+		}
+		if err != nil {
+			rWrtr.CloseWithError(err)
+		}
+	}()
+
+	return rRdr
+}
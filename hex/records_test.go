@@ -0,0 +1,233 @@
+package hex
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const (
+	testRecordText = "Hello, World!\n\x00\x01\x02 and some more data here..."
+	testIntelHex   = ":08FFF80048656C6C6F2C20576A\n" +
+		":020000040001F9\n" +
+		":080000006F726C64210A00011B\n" +
+		":100008000220616E6420736F6D65206D6F726520CC\n" +
+		":0C0018006461746120686572652E2E2EF4\n" +
+		":00000001FF\n"
+	testSRecord = "S0030000FC\n" +
+		"S21400FFF848656C6C6F2C20576F726C64210A000180\n" +
+		"S2140100080220616E6420736F6D65206D6F726520C6\n" +
+		"S2100100186461746120686572652E2E2EEE\n" +
+		"S5030003F9\n" +
+		"S80400FFF804\n"
+)
+
+func TestToIntelHex(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testRecordText), opts: []Option{WithBaseAddress(0xfff8)}},
+			want: testIntelHex,
+		},
+		{
+			name: "Columns",
+			args: args{r: strings.NewReader("ABCDE"), opts: []Option{WithColumns(4)}},
+			want: ":0400000041424344F2\n:0100040045B6\n:00000001FF\n",
+		},
+		{
+			name: "Empty",
+			args: args{r: strings.NewReader("")},
+			want: ":00000001FF\n",
+		},
+		{
+			name:    "TooHigh",
+			args:    args{r: strings.NewReader("ABCDE"), opts: []Option{WithBaseAddress(0xfffffffe)}},
+			want:    "",
+			wantErr: ErrFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(ToIntelHex(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToIntelHex() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToIntelHex() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToSRecord(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testRecordText), opts: []Option{WithBaseAddress(0xfff8)}},
+			want: testSRecord,
+		},
+		{
+			name: "S1",
+			args: args{r: strings.NewReader("ABCDE"), opts: []Option{WithBaseAddress(0x100)}},
+			want: "S0030000FC\nS10801004142434445A7\nS5030001FB\nS9030100FB\n",
+		},
+		{
+			name: "S3",
+			args: args{r: strings.NewReader("ABCDE"), opts: []Option{WithBaseAddress(0x1000000)}},
+			want: "S0030000FC\nS30A010000004142434445A5\nS5030001FB\nS70501000000F9\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToSRecord(tt.args.r, tt.args.opts...)); string(got) != tt.want {
+				t.Errorf("ToSRecord() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromIntelHex(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testIntelHex)},
+			want: testRecordText,
+		},
+		{
+			name: "Sparse",
+			args: args{r: strings.NewReader(":0400000041424344F2\r\n\r\n:0100080045B2\r\n:00000001FF\r\n")},
+			want: "ABCD\xff\xff\xff\xffE",
+		},
+		{
+			name: "FillAndBase",
+			args: args{r: strings.NewReader(":0100080045B2\n:00000001FF\n"), opts: []Option{WithBaseAddress(4), WithFill(0)}},
+			want: "\x00\x00\x00\x00E",
+		},
+		{
+			name: "SegmentAddress",
+			args: args{r: strings.NewReader(":020000021000EC\n:0100000041BE\n:0100100042AD\n:00000001FF\n"), opts: []Option{WithBaseAddress(0x10000)}},
+			want: "A" + strings.Repeat("\xff", 15) + "B",
+		},
+		{
+			name:    "BadChecksum",
+			args:    args{r: strings.NewReader(":0400000041424344F2\n:0100040045B7\n:00000001FF\n")},
+			want:    "ABCD",
+			wantErr: ErrChecksum,
+		},
+		{
+			name:    "OutOfOrder",
+			args:    args{r: strings.NewReader(":0100040045B6\n:0400000041424344F2\n:00000001FF\n")},
+			want:    "E",
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "HugeGap",
+			args:    args{r: strings.NewReader(":0100000041BE\n:02000004FFFFFC\n:0100000042BD\n:00000001FF\n")},
+			want:    "A",
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "NoEOF",
+			args:    args{r: strings.NewReader(":0400000041424344F2\n")},
+			want:    "ABCD",
+			wantErr: ErrFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromIntelHex(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromIntelHex() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromIntelHex() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromSRecord(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testSRecord)},
+			want: testRecordText,
+		},
+		{
+			name: "Sparse",
+			args: args{r: strings.NewReader("S107000041424344EE\nS104000845AE\nS9030000FC\n"), opts: []Option{WithFill('.')}},
+			want: "ABCD....E",
+		},
+		{
+			name:    "BadChecksum",
+			args:    args{r: strings.NewReader("S107000041424344EF\nS9030000FC\n")},
+			want:    "",
+			wantErr: ErrChecksum,
+		},
+		{
+			name:    "BadCount",
+			args:    args{r: strings.NewReader("S107000041424344EE\nS5030002FA\nS9030000FC\n")},
+			want:    "ABCD",
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "HugeGap",
+			args:    args{r: strings.NewReader("S104000041BA\nS3060200000042B5\nS70500000000FA\n")},
+			want:    "A",
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "UnknownType",
+			args:    args{r: strings.NewReader("S4030000FC\n")},
+			want:    "",
+			wantErr: ErrFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromSRecord(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromSRecord() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromSRecord() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/hmac
+
+go 1.24.2
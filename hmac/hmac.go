@@ -0,0 +1,194 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hmac defines filters to authenticate a stream of data using
+// HMAC-SHA256.  The stream is split into chunks and each chunk is
+// authenticated along with its sequence number and a final-chunk flag, so
+// that truncation and reordering of the chunks are detected.  On decode, only
+// chunks that have been verified are released.  These filters can be
+// connected to other filters via io.Pipes.
+//
+// The authenticated stream has the form:
+//
+//	stream id (16 random bytes)
+//	chunk...
+//
+// where each chunk is:
+//
+//	flags (1 byte, 0x01 on the final chunk)
+//	length of data (4 bytes, big endian)
+//	data
+//	HMAC-SHA256(key, stream id || sequence number (8 bytes, big endian) ||
+//	            flags || length || data)
+//
+// The final chunk may be empty.
+package hmac
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// ChunkSize is the maximum number of data bytes in a chunk.
+	ChunkSize = 64 * 1024
+	// IDSize is the size of the random stream id.
+	IDSize = 16
+	// TagSize is the size of the HMAC-SHA256 tag appended to each chunk.
+	TagSize = sha256.Size
+
+	flagFinal  = 0x01
+	headerSize = 5
+)
+
+var (
+	// ErrAuthentication is returned when a chunk fails verification.
+	ErrAuthentication = errors.New("hmac: message authentication failed")
+	// ErrTruncated is returned when the stream ends before the final chunk.
+	ErrTruncated = errors.New("hmac: authenticated stream is truncated")
+)
+
+// computeTag calculates the tag for a chunk.
+func computeTag(key, id []byte, seq uint64, hdr, data []byte) []byte {
+	var seqBytes [8]byte
+	binary.BigEndian.PutUint64(seqBytes[:], seq)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(id)
+	mac.Write(seqBytes[:])
+	mac.Write(hdr)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// ToHMAC reads data from r, splits it into chunks and authenticates each
+// chunk using key.  The authenticated stream can be read using the returned
+// PipeReader.
+func ToHMAC(r io.Reader, key []byte) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		if len(key) == 0 {
+			rWrtr.CloseWithError(fmt.Errorf("hmac: empty key"))
+			return
+		}
+		id := make([]byte, IDSize)
+		if _, err := rand.Read(id); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error generating a stream id: %w", err))
+			return
+		}
+		if _, err := rWrtr.Write(id); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing the stream id to an io.PipeWriter: %w", err))
+			return
+		}
+		// Read ahead by one byte so that the final chunk can be flagged.
+		bRdr := bufio.NewReaderSize(r, ChunkSize)
+		buf := make([]byte, ChunkSize)
+		hdr := make([]byte, headerSize)
+		for seq := uint64(0); ; seq++ {
+			n, err := io.ReadFull(bRdr, buf)
+			final := false
+			switch {
+			case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+				final = true
+			case err != nil:
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			default:
+				if _, err = bRdr.Peek(1); errors.Is(err, io.EOF) {
+					final = true
+				} else if err != nil {
+					rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+					return
+				}
+			}
+			hdr[0] = 0
+			if final {
+				hdr[0] = flagFinal
+			}
+			binary.BigEndian.PutUint32(hdr[1:], uint32(n))
+			tag := computeTag(key, id, seq, hdr, buf[:n])
+			for _, b := range [][]byte{hdr, buf[:n], tag} {
+				if _, err = rWrtr.Write(b); err != nil {
+					rWrtr.CloseWithError(fmt.Errorf("error writing a chunk to an io.PipeWriter: %w", err))
+					return
+				}
+			}
+			if final {
+				return
+			}
+		}
+	}()
+
+	return rRdr
+}
+
+// FromHMAC reads a stream authenticated by ToHMAC from r and verifies each
+// chunk using key.  Only verified data can be read using the returned
+// PipeReader.  If a chunk fails verification, or the stream is truncated,
+// the read returns an error.
+func FromHMAC(r io.Reader, key []byte) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		if len(key) == 0 {
+			rWrtr.CloseWithError(fmt.Errorf("hmac: empty key"))
+			return
+		}
+		id := make([]byte, IDSize)
+		if _, err := io.ReadFull(r, id); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error reading the stream id: %w", ErrTruncated))
+			return
+		}
+		hdr := make([]byte, headerSize)
+		buf := make([]byte, ChunkSize+TagSize)
+		for seq := uint64(0); ; seq++ {
+			if _, err := io.ReadFull(r, hdr); err != nil {
+				if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+					err = ErrTruncated
+				}
+				rWrtr.CloseWithError(fmt.Errorf("error reading chunk %d: %w", seq, err))
+				return
+			}
+			n := binary.BigEndian.Uint32(hdr[1:])
+			if hdr[0]&^flagFinal != 0 || n > ChunkSize {
+				rWrtr.CloseWithError(fmt.Errorf("chunk %d has an invalid header: %w", seq, ErrAuthentication))
+				return
+			}
+			chunk := buf[:int(n)+TagSize]
+			if _, err := io.ReadFull(r, chunk); err != nil {
+				if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+					err = ErrTruncated
+				}
+				rWrtr.CloseWithError(fmt.Errorf("error reading chunk %d: %w", seq, err))
+				return
+			}
+			data, tag := chunk[:n], chunk[n:]
+			if !hmac.Equal(tag, computeTag(key, id, seq, hdr, data)) {
+				rWrtr.CloseWithError(fmt.Errorf("chunk %d failed verification: %w", seq, ErrAuthentication))
+				return
+			}
+			if _, err := rWrtr.Write(data); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+			if hdr[0]&flagFinal != 0 {
+				break
+			}
+		}
+		// Nothing may follow the final chunk.
+		if n, _ := io.ReadFull(r, make([]byte, 1)); n != 0 {
+			rWrtr.CloseWithError(fmt.Errorf("data found after the final chunk: %w", ErrAuthentication))
+		}
+	}()
+
+	return rRdr
+}
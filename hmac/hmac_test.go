@@ -0,0 +1,104 @@
+package hmac
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+var testKey = []byte("This is only a test key")
+
+func TestRoundTrip(t *testing.T) {
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Empty",
+			args: args{data: []byte{}},
+		},
+		{
+			name: "TestOne",
+			args: args{data: []byte("This is only a test")},
+		},
+		{
+			name: "ExactChunk",
+			args: args{data: bytes.Repeat([]byte{'a'}, ChunkSize)},
+		},
+		{
+			name: "ManyChunks",
+			args: args{data: bytes.Repeat([]byte("0123456789"), ChunkSize/3)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromHMAC(ToHMAC(bytes.NewReader(tt.args.data), testKey), testKey))
+			if err != nil {
+				t.Fatalf("FromHMAC() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.args.data) {
+				t.Errorf("FromHMAC() returned %d bytes, want %d", len(got), len(tt.args.data))
+			}
+		})
+	}
+}
+
+// chunks splits an authenticated stream into its stream id and chunks.
+func chunks(stream []byte) ([]byte, [][]byte) {
+	id, rest := stream[:IDSize], stream[IDSize:]
+	var c [][]byte
+	for len(rest) > 0 {
+		n := int(rest[1])<<24 | int(rest[2])<<16 | int(rest[3])<<8 | int(rest[4])
+		c = append(c, rest[:headerSize+n+TagSize])
+		rest = rest[headerSize+n+TagSize:]
+	}
+	return id, c
+}
+
+func TestFromHMACErrors(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), ChunkSize/4)
+	stream, _ := io.ReadAll(ToHMAC(bytes.NewReader(data), testKey))
+	id, c := chunks(stream)
+	if len(c) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(c))
+	}
+	tampered := bytes.Clone(stream)
+	tampered[IDSize+headerSize+10] ^= 0x01
+	tests := []struct {
+		name string
+		in   []byte
+		key  []byte
+		want error
+	}{
+		{name: "WrongKey", in: stream, key: []byte("wrong key"), want: ErrAuthentication},
+		{name: "Tampered", in: tampered, key: testKey, want: ErrAuthentication},
+		{name: "Truncated", in: bytes.Join([][]byte{id, c[0], c[1]}, nil), key: testKey, want: ErrTruncated},
+		{name: "Reordered", in: bytes.Join([][]byte{id, c[1], c[0], c[2]}, nil), key: testKey, want: ErrAuthentication},
+		{name: "Trailing", in: bytes.Join([][]byte{stream, c[2]}, nil), key: testKey, want: ErrAuthentication},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := io.ReadAll(FromHMAC(bytes.NewReader(tt.in), tt.key)); !errors.Is(err, tt.want) {
+				t.Errorf("FromHMAC() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromHMACReleasesVerifiedChunks(t *testing.T) {
+	data := strings.Repeat("x", ChunkSize+10)
+	stream, _ := io.ReadAll(ToHMAC(strings.NewReader(data), testKey))
+	stream[len(stream)-1] ^= 0x01
+	got, err := io.ReadAll(FromHMAC(bytes.NewReader(stream), testKey))
+	if !errors.Is(err, ErrAuthentication) {
+		t.Errorf("FromHMAC() error = %v, want %v", err, ErrAuthentication)
+	}
+	if len(got) != ChunkSize {
+		t.Errorf("FromHMAC() released %d bytes, want %d", len(got), ChunkSize)
+	}
+}
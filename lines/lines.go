@@ -18,15 +18,44 @@ import (
 // LineSize defines the number of character that are put into a line by the
 // SplitToLines function.  The default is 72 characters per line.  Change
 // it prior to calling SplitToLines to change the number of characters
-// per line.
+// per line.  Since LineSize is shared by every caller, prefer passing the
+// WithLineSize option to SplitToLines instead.
 var LineSize int = 72
 
+// An Option configures the behaviour of SplitToLines.
+type Option func(*options)
+
+type options struct {
+	lineSize    int
+	lineSizeSet bool
+}
+
+// WithLineSize sets the number of characters per line for a single call to
+// SplitToLines, overriding LineSize.
+func WithLineSize(n int) Option {
+	return func(o *options) {
+		o.lineSize, o.lineSizeSet = n, true
+	}
+}
+
 // SplitToLines reads a stream of ASCII characters (usually the output from
-// ascii85) from r and splits it into lines of 'LineSize' characters.  The
-// lines can be read from the returned PipeReader.
-func SplitToLines(r io.Reader) *io.PipeReader {
+// ascii85) from r and splits it into lines of 'LineSize' characters, or the
+// number of characters given by the WithLineSize option.  The lines can be
+// read from the returned PipeReader.
+func SplitToLines(r io.Reader, opts ...Option) *io.PipeReader {
 	rRdr, rWtr := io.Pipe()
-	line := make([]byte, LineSize)
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.lineSizeSet {
+		o.lineSize = LineSize
+	}
+	if o.lineSize <= 0 {
+		rWtr.CloseWithError(fmt.Errorf("invalid line size: %d", o.lineSize))
+		return rRdr
+	}
+	line := make([]byte, o.lineSize)
 
 	go func() {
 		defer rWtr.Close()
@@ -54,3 +54,51 @@ func TestCombineLines(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitToLinesWithLineSize(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		size int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("This is only a test of the SplitToLines filter."), size: 16},
+			want: "This is only a t\nest of the Split\nToLines filter.\n",
+		},
+	}
+	saved := LineSize
+	defer func() { LineSize = saved }()
+	LineSize = 10
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(SplitToLines(tt.args.r, WithLineSize(tt.args.size))); strings.Compare(string(got), tt.want) != 0 {
+				t.Errorf("SplitToLines() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitToLinesWithLineSizeConcurrent(t *testing.T) {
+	// SplitToLines must not read LineSize when WithLineSize is given, so
+	// changing LineSize at the same time is not a data race (go test -race).
+	saved := LineSize
+	defer func() { LineSize = saved }()
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			LineSize = 10 + i%2
+		}
+		close(done)
+	}()
+	for i := 0; i < 100; i++ {
+		if got, _ := io.ReadAll(SplitToLines(strings.NewReader("abcdef"), WithLineSize(4))); string(got) != "abcd\nef\n" {
+			t.Fatalf("SplitToLines() = %q, want %q", got, "abcd\nef\n")
+		}
+	}
+	<-done
+}
@@ -0,0 +1,343 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pem
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A Cipher identifies the algorithm used to encrypt the body of a PEM block
+// (RFC 1421).
+type Cipher int
+
+// The supported ciphers.  The CBC ciphers are compatible with OpenSSL's
+// traditional encrypted PEM format, including its single iteration MD5 key
+// derivation.  The GCM ciphers, which OpenSSL does not support, authenticate
+// the body as well, but must hold the whole body in memory.  They derive
+// their key with PBKDF2-SHA256, and the DEK-Info header gives the salt and
+// iteration count after the nonce:
+//
+//	DEK-Info: AES-256-GCM,<nonce>,<salt>,<iterations>
+const (
+	AES128CBC Cipher = iota + 1
+	AES192CBC
+	AES256CBC
+	AES128GCM
+	AES256GCM
+)
+
+const (
+	procTypeKey       = "Proc-Type"
+	procTypeEncrypted = "4,ENCRYPTED"
+	dekInfoKey        = "DEK-Info"
+
+	// DefaultIterations is the PBKDF2 iteration count used for the GCM
+	// ciphers.
+	DefaultIterations = 600000
+	// saltSize is the size of the random PBKDF2 salt.
+	saltSize = 16
+	// maxIterations bounds the iteration count read from a DEK-Info
+	// header, so that a hostile block cannot force a long key derivation.
+	maxIterations = 10 * DefaultIterations
+)
+
+// iterations is the PBKDF2 iteration count used by ToPem.  The tests lower
+// it to keep them fast.
+var iterations = DefaultIterations
+
+// ErrDecryption is returned when the body of an encrypted PEM block cannot
+// be decrypted, usually because the passphrase is wrong.
+var ErrDecryption = errors.New("pem: decryption failed (wrong passphrase?)")
+
+var cipherInfo = map[Cipher]struct {
+	name    string
+	keySize int
+	ivSize  int
+	gcm     bool
+}{
+	AES128CBC: {"AES-128-CBC", 16, aes.BlockSize, false},
+	AES192CBC: {"AES-192-CBC", 24, aes.BlockSize, false},
+	AES256CBC: {"AES-256-CBC", 32, aes.BlockSize, false},
+	AES128GCM: {"AES-128-GCM", 16, 12, true},
+	AES256GCM: {"AES-256-GCM", 32, 12, true},
+}
+
+// String returns the name of the cipher as used in the DEK-Info header.
+func (c Cipher) String() string {
+	if info, ok := cipherInfo[c]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("Cipher(%d)", int(c))
+}
+
+// cipherByName returns the Cipher named in a DEK-Info header.
+func cipherByName(name string) (Cipher, bool) {
+	for c, info := range cipherInfo {
+		if info.name == name {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// dek holds the parameters given in a DEK-Info header.  The salt and
+// iteration count are only used by the GCM ciphers.
+type dek struct {
+	cipher     Cipher
+	iv         []byte
+	salt       []byte
+	iterations int
+}
+
+// newDEK returns the parameters, with a random IV (or nonce) and salt, for
+// encrypting with cipher c.
+func newDEK(c Cipher) (dek, error) {
+	d := dek{cipher: c, iv: make([]byte, cipherInfo[c].ivSize)}
+	if _, err := rand.Read(d.iv); err != nil {
+		return dek{}, fmt.Errorf("pem: error generating an IV: %w", err)
+	}
+	if cipherInfo[c].gcm {
+		d.salt, d.iterations = make([]byte, saltSize), iterations
+		if _, err := rand.Read(d.salt); err != nil {
+			return dek{}, fmt.Errorf("pem: error generating a salt: %w", err)
+		}
+	}
+	return d, nil
+}
+
+// String returns the value of the DEK-Info header for d.
+func (d dek) String() string {
+	v := d.cipher.String() + "," + strings.ToUpper(hex.EncodeToString(d.iv))
+	if cipherInfo[d.cipher].gcm {
+		v += "," + strings.ToUpper(hex.EncodeToString(d.salt)) + "," + strconv.Itoa(d.iterations)
+	}
+	return v
+}
+
+// key derives the key for d from passphrase.  The CBC ciphers use the first
+// eight bytes of the IV as the salt in the same way as OpenSSL's
+// EVP_BytesToKey using MD5 and one iteration; the GCM ciphers use PBKDF2.
+func (d dek) key(passphrase []byte) ([]byte, error) {
+	keySize := cipherInfo[d.cipher].keySize
+	if cipherInfo[d.cipher].gcm {
+		key, err := pbkdf2.Key(sha256.New, string(passphrase), d.salt, d.iterations, keySize)
+		if err != nil {
+			return nil, fmt.Errorf("pem: error deriving a key from the passphrase: %w", err)
+		}
+		return key, nil
+	}
+	salt := d.iv[:8]
+	var key, digest []byte
+	for len(key) < keySize {
+		h := md5.New()
+		h.Write(digest)
+		h.Write(passphrase)
+		h.Write(salt)
+		digest = h.Sum(nil)
+		key = append(key, digest...)
+	}
+	return key[:keySize], nil
+}
+
+// encrypt reads data from r and encrypts it using the cipher and IV in d and
+// a key derived from passphrase.  The encrypted data can be read using the
+// returned PipeReader.
+func encrypt(r io.Reader, d dek, passphrase []byte) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	info, iv := cipherInfo[d.cipher], d.iv
+
+	go func() {
+		defer rWrtr.Close()
+		key, err := d.key(passphrase)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("pem: %w", err))
+			return
+		}
+		if info.gcm {
+			gcm, err := cipher.NewGCM(block)
+			if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("pem: %w", err))
+				return
+			}
+			plain, err := io.ReadAll(r)
+			if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			if _, err = rWrtr.Write(gcm.Seal(nil, iv, plain, nil)); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+			}
+			return
+		}
+		cbc := cipher.NewCBCEncrypter(block, iv)
+		buf := make([]byte, 256*aes.BlockSize)
+		for {
+			n, err := io.ReadFull(r, buf)
+			if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			last := err != nil
+			if last {
+				// Add PKCS #7 padding to the final block.
+				pad := aes.BlockSize - n%aes.BlockSize
+				n += copy(buf[n:], bytes.Repeat([]byte{byte(pad)}, pad))
+			}
+			cbc.CryptBlocks(buf[:n], buf[:n])
+			if _, err = rWrtr.Write(buf[:n]); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+			if last {
+				return
+			}
+		}
+	}()
+
+	return rRdr
+}
+
+// parseDEKInfo parses the value of a DEK-Info header.
+func parseDEKInfo(v string) (dek, error) {
+	fields := strings.Split(v, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	c, ok := cipherByName(fields[0])
+	if !ok {
+		return dek{}, fmt.Errorf("pem: unsupported DEK-Info cipher %q", fields[0])
+	}
+	info := cipherInfo[c]
+	want := 2
+	if info.gcm {
+		want = 4
+	}
+	if len(fields) != want {
+		return dek{}, fmt.Errorf("pem: malformed DEK-Info header %q", v)
+	}
+	d := dek{cipher: c}
+	var err error
+	if d.iv, err = hex.DecodeString(fields[1]); err != nil || len(d.iv) != info.ivSize {
+		return dek{}, fmt.Errorf("pem: malformed DEK-Info IV %q", fields[1])
+	}
+	if info.gcm {
+		if d.salt, err = hex.DecodeString(fields[2]); err != nil || len(d.salt) == 0 {
+			return dek{}, fmt.Errorf("pem: malformed DEK-Info salt %q", fields[2])
+		}
+		if d.iterations, err = strconv.Atoi(fields[3]); err != nil || d.iterations <= 0 || d.iterations > maxIterations {
+			return dek{}, fmt.Errorf("pem: invalid DEK-Info iteration count %q", fields[3])
+		}
+	}
+	return d, nil
+}
+
+// decrypt reads data encrypted by encrypt from r and decrypts it using the
+// cipher and IV given in dekInfo and a key derived from the passphrase
+// returned by passphrase.  The decrypted data can be read using the
+// returned PipeReader.
+func decrypt(r io.Reader, dekInfo string, passphrase func() ([]byte, error)) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		d, err := parseDEKInfo(dekInfo)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		pass, err := passphrase()
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("pem: error getting the passphrase: %w", err))
+			return
+		}
+		info, iv := cipherInfo[d.cipher], d.iv
+		key, err := d.key(pass)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("pem: %w", err))
+			return
+		}
+		if info.gcm {
+			gcm, err := cipher.NewGCM(block)
+			if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("pem: %w", err))
+				return
+			}
+			sealed, err := io.ReadAll(r)
+			if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			plain, err := gcm.Open(nil, iv, sealed, nil)
+			if err != nil {
+				rWrtr.CloseWithError(ErrDecryption)
+				return
+			}
+			if _, err = rWrtr.Write(plain); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+			}
+			return
+		}
+		// The last block is held back so that the padding can be removed.
+		cbc := cipher.NewCBCDecrypter(block, iv)
+		buf := make([]byte, 256*aes.BlockSize+aes.BlockSize)
+		held := 0
+		for {
+			n, err := io.ReadFull(r, buf[held:])
+			if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			n += held
+			last := err != nil
+			if !last {
+				cbc.CryptBlocks(buf[:n-aes.BlockSize], buf[:n-aes.BlockSize])
+				if _, err = rWrtr.Write(buf[:n-aes.BlockSize]); err != nil {
+					rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+					return
+				}
+				held = copy(buf, buf[n-aes.BlockSize:n])
+				continue
+			}
+			if n == 0 || n%aes.BlockSize != 0 {
+				rWrtr.CloseWithError(ErrDecryption)
+				return
+			}
+			cbc.CryptBlocks(buf[:n], buf[:n])
+			pad := int(buf[n-1])
+			if pad == 0 || pad > aes.BlockSize || !bytes.Equal(buf[n-pad:n], bytes.Repeat([]byte{byte(pad)}, pad)) {
+				rWrtr.CloseWithError(ErrDecryption)
+				return
+			}
+			if _, err = rWrtr.Write(buf[:n-pad]); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+			}
+			return
+		}
+	}()
+
+	return rRdr
+}
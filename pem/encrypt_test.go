@@ -0,0 +1,108 @@
+package pem
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	stdpem "encoding/pem"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func init() {
+	// A low iteration count keeps the tests fast.
+	iterations = 1000
+}
+
+func passphrase(p string) func() ([]byte, error) {
+	return func() ([]byte, error) { return []byte(p), nil }
+}
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	want := strings.Repeat("This is only a test.  ", 500)
+	for _, c := range []Cipher{AES128CBC, AES192CBC, AES256CBC, AES128GCM, AES256GCM} {
+		t.Run(c.String(), func(t *testing.T) {
+			enc, err := io.ReadAll(ToPem(strings.NewReader(want), Block{Type: "TEST"}, WithEncryption(c, []byte("secret"))))
+			if err != nil {
+				t.Fatalf("ToPem() error = %v", err)
+			}
+			lines := strings.Split(string(enc), "\n")
+			if lines[1] != "Proc-Type: 4,ENCRYPTED" || !strings.HasPrefix(lines[2], "DEK-Info: "+c.String()+",") {
+				t.Errorf("ToPem() headers = %q, %q", lines[1], lines[2])
+			}
+			rdr, blk := FromPem(bytes.NewReader(enc), WithPassphrase(passphrase("secret")))
+			got, err := io.ReadAll(rdr)
+			if err != nil || string(got) != want {
+				t.Errorf("FromPem() error = %v, returned %d bytes, want %d", err, len(got), len(want))
+			}
+			if blk.Headers["Proc-Type"] != "4,ENCRYPTED" {
+				t.Errorf("FromPem() Proc-Type = %q", blk.Headers["Proc-Type"])
+			}
+			rdr, _ = FromPem(bytes.NewReader(enc), WithPassphrase(passphrase("wrong")))
+			if _, err = io.ReadAll(rdr); !errors.Is(err, ErrDecryption) {
+				t.Errorf("FromPem() with the wrong passphrase error = %v, want %v", err, ErrDecryption)
+			}
+		})
+	}
+}
+
+func TestFromPemOpenSSLCompatible(t *testing.T) {
+	want := []byte("This is only a test of an OpenSSL compatible encrypted PEM block.")
+	//lint:ignore SA1019 used to check compatibility with the legacy format.
+	stdBlk, err := x509.EncryptPEMBlock(rand.Reader, "TEST", want, []byte("secret"), x509.PEMCipherAES256)
+	if err != nil {
+		t.Fatalf("x509.EncryptPEMBlock() error = %v", err)
+	}
+	rdr, _ := FromPem(bytes.NewReader(stdpem.EncodeToMemory(stdBlk)), WithPassphrase(passphrase("secret")))
+	if got, err := io.ReadAll(rdr); err != nil || !bytes.Equal(got, want) {
+		t.Errorf("FromPem() = %q, %v, want %q", got, err, want)
+	}
+}
+
+func TestParseDEKInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "TestOne",
+			v:    "AES-256-CBC,000102030405060708090A0B0C0D0E0F",
+			want: "AES-256-CBC,000102030405060708090A0B0C0D0E0F",
+		},
+		{
+			name: "GCM",
+			v:    "AES-128-GCM, 000102030405060708090A0B, 0F0E0D0C0B0A09080706050403020100, 600000",
+			want: "AES-128-GCM,000102030405060708090A0B,0F0E0D0C0B0A09080706050403020100,600000",
+		},
+		{
+			name:    "GCMWithoutSalt",
+			v:       "AES-128-GCM,000102030405060708090A0B",
+			wantErr: true,
+		},
+		{
+			name:    "TooManyIterations",
+			v:       "AES-128-GCM,000102030405060708090A0B,0F0E0D0C0B0A09080706050403020100,6000001",
+			wantErr: true,
+		},
+		{
+			name:    "BadIV",
+			v:       "AES-128-CBC,0001",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDEKInfo(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDEKInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("parseDEKInfo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,5 +4,5 @@ go 1.24.2
 
 require (
 	github.com/bgallie/filters/base64 v0.0.0-20250416201050-bb5407c2b958
-	github.com/bgallie/filters/lines v0.0.0-20250416201050-bb5407c2b958
+	github.com/bgallie/filters/lines v0.0.0-20261019154801-6e313acf06b6
 )
@@ -0,0 +1,189 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pem
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Mode selects how strictly FromPem and Reader parse PEM data.
+type Mode int
+
+const (
+	// Lax accepts CRLF line endings, whitespace around the delimiter lines
+	// and header values and within the base64 data, and RFC 1421 headers,
+	// including continuation lines that start with a space or tab.  This is
+	// the default.
+	Lax Mode = iota
+	// Strict accepts only the strict form of RFC 7468: no headers, no
+	// whitespace other than line endings, a label made up of printable
+	// characters, and base64 lines of exactly 64 characters except for the
+	// last one.
+	Strict
+)
+
+// strictLineLength is the length of every base64 line but the last in
+// strict mode.
+const strictLineLength = 64
+
+// WithMode selects the parsing mode used by FromPem and Reader.
+func WithMode(m Mode) Option {
+	return func(o *options) {
+		o.mode = m
+	}
+}
+
+// A ParseError reports a malformed PEM block and the line it was found on.
+type ParseError struct {
+	Line int    // The line number, starting at 1.
+	Msg  string // A description of the problem.
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("pem: line %d: %s", e.Line, e.Msg)
+}
+
+// lineReader reads lines of text and counts them.
+type lineReader struct {
+	bRdr *bufio.Reader
+	line int
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{bRdr: bufio.NewReader(r)}
+}
+
+// readLine returns the next line without its line ending (LF or CRLF).
+func (lr *lineReader) readLine() (string, error) {
+	line, err := lr.bRdr.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+		return "", err
+	}
+	lr.line++
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
+
+// validLabel reports if label is a valid RFC 7468 label: printable
+// characters other than '-', optionally separated by single spaces or
+// hyphens.
+func validLabel(label string) bool {
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		switch {
+		case c == ' ' || c == '-':
+			if i == 0 || i == len(label)-1 || label[i-1] == ' ' || label[i-1] == '-' {
+				return false
+			}
+		case c < 0x21 || c > 0x7e:
+			return false
+		}
+	}
+	return true
+}
+
+// parseDelimiter returns the label from a "-----BEGIN label-----" or
+// "-----END label-----" line, where kind is "BEGIN" or "END".
+func parseDelimiter(line, kind string, mode Mode) (string, bool) {
+	if mode == Lax {
+		line = strings.TrimSpace(line)
+	}
+	prefix := "-----" + kind + " "
+	if !strings.HasPrefix(line, prefix) || !strings.HasSuffix(line, "-----") || len(line) < len(prefix)+5 {
+		return "", false
+	}
+	label := line[len(prefix) : len(line)-5]
+	if mode == Strict && !validLabel(label) {
+		return "", false
+	}
+	return label, true
+}
+
+// readHeaders reads the headers that follow the BEGIN line into blk and
+// returns the first line after them.
+func readHeaders(lr *lineReader, blk *Block, mode Mode) (string, error) {
+	addHeader := func(k, v string) {
+		blk.Headers[k] = v
+		blk.HeaderList = append(blk.HeaderList, Header{Key: k, Value: v})
+	}
+	var key, value string
+	pending := false
+	for {
+		line, err := lr.readLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", &ParseError{Line: lr.line, Msg: fmt.Sprintf("missing END line for %q", blk.Type)}
+			}
+			return "", fmt.Errorf("pem: error reading from an io.Reader: %w", err)
+		}
+		if mode == Strict {
+			if strings.Contains(line, ":") {
+				return "", &ParseError{Line: lr.line, Msg: "headers are not allowed in strict mode"}
+			}
+			return line, nil
+		}
+		if pending && line != "" && (line[0] == ' ' || line[0] == '\t') {
+			// A continuation of the previous header.
+			value += line
+			continue
+		}
+		if pending {
+			addHeader(key, strings.TrimSpace(value))
+			pending = false
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if ok && !strings.HasPrefix(line, "-----") {
+			key, value, pending = strings.TrimSpace(k), v, true
+			if key == "" {
+				return "", &ParseError{Line: lr.line, Msg: "header with an empty key"}
+			}
+			continue
+		}
+		if len(blk.HeaderList) > 0 && strings.TrimSpace(line) == "" {
+			// The blank line that separates the headers from the body.
+			line, err = lr.readLine()
+			if errors.Is(err, io.EOF) {
+				return "", &ParseError{Line: lr.line, Msg: fmt.Sprintf("missing END line for %q", blk.Type)}
+			} else if err != nil {
+				return "", fmt.Errorf("pem: error reading from an io.Reader: %w", err)
+			}
+		}
+		return line, nil
+	}
+}
+
+// A bodyChecker validates the base64 lines of a block body.
+type bodyChecker struct {
+	mode  Mode
+	short int // The line number of a short line in strict mode.
+}
+
+// check validates the body line read from line number n and returns the
+// base64 data it holds.
+func (bc *bodyChecker) check(line string, n int) (string, error) {
+	if bc.mode == Lax {
+		return strings.Join(strings.Fields(line), ""), nil
+	}
+	if bc.short != 0 {
+		return "", &ParseError{Line: bc.short, Msg: fmt.Sprintf("base64 line is shorter than %d characters", strictLineLength)}
+	}
+	if len(line) > strictLineLength {
+		return "", &ParseError{Line: n, Msg: fmt.Sprintf("base64 line is longer than %d characters", strictLineLength)}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if !('A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '+' || c == '/' || c == '=') {
+			return "", &ParseError{Line: n, Msg: fmt.Sprintf("invalid character %q in base64 data", c)}
+		}
+	}
+	if len(line) < strictLineLength {
+		bc.short = n
+	}
+	return line, nil
+}
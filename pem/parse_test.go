@@ -0,0 +1,114 @@
+package pem
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromPemModes(t *testing.T) {
+	full := "VGhlIHF1aWNrIGJyb3duIGZveCBqdW1wZWQgb3ZlciB0aGUgbGF6eSBkb2cuICBU"
+	last := "aGUgcXVpY2sgYnJvd24gZm94IGp1bXBlZCBvdmVyIHRoZSBsYXp5IGRvZy4="
+	text := "The quick brown fox jumped over the lazy dog.  The quick brown fox jumped over the lazy dog."
+	type args struct {
+		in   string
+		mode Mode
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     string
+		wantBlk  Block
+		wantLine int // The line of the expected *ParseError, or 0.
+	}{
+		{
+			name:    "LaxHyphenatedType",
+			args:    args{in: "-----BEGIN OPENSSH-STYLE KEY-----\n" + full + "\n" + last + "\n-----END OPENSSH-STYLE KEY-----\n"},
+			want:    text,
+			wantBlk: Block{Type: "OPENSSH-STYLE KEY", Headers: map[string]string{}},
+		},
+		{
+			name: "LaxCRLFAndWhitespace",
+			args: args{in: "-----BEGIN TEST-----  \r\nCOUNT:100  \r\n\r\n " + full[:10] + " " + full[10:] + "\r\n" + last +
+				"\t\r\n-----END TEST----- \r\n"},
+			want:    text,
+			wantBlk: Block{Type: "TEST", Headers: map[string]string{"COUNT": "100"}, HeaderList: []Header{{"COUNT", "100"}}},
+		},
+		{
+			name: "LaxContinuation",
+			args: args{in: "-----BEGIN TEST-----\nProc-Type: 4,ENCRYPTED\nComment: The quick\n  brown fox\n\n" +
+				full + "\n" + last + "\n-----END TEST-----\n"},
+			want: text,
+			wantBlk: Block{Type: "TEST",
+				Headers:    map[string]string{"Proc-Type": "4,ENCRYPTED", "Comment": "The quick  brown fox"},
+				HeaderList: []Header{{"Proc-Type", "4,ENCRYPTED"}, {"Comment", "The quick  brown fox"}}},
+		},
+		{
+			name:    "Strict",
+			args:    args{in: "-----BEGIN X509 CRL-----\r\n" + full + "\r\n" + last + "\r\n-----END X509 CRL-----\r\n", mode: Strict},
+			want:    text,
+			wantBlk: Block{Type: "X509 CRL", Headers: map[string]string{}},
+		},
+		{
+			name:     "StrictHeaders",
+			args:     args{in: "-----BEGIN TEST-----\nCOUNT: 100\n" + full + "\n-----END TEST-----\n", mode: Strict},
+			wantBlk:  Block{Type: "TEST", Headers: map[string]string{}},
+			wantLine: 2,
+		},
+		{
+			name:     "StrictShortLine",
+			args:     args{in: "-----BEGIN TEST-----\n" + last + "\n" + full + "\n-----END TEST-----\n", mode: Strict},
+			wantBlk:  Block{Type: "TEST", Headers: map[string]string{}},
+			wantLine: 2,
+		},
+		{
+			name:     "StrictWhitespace",
+			args:     args{in: "-----BEGIN TEST-----\n" + full + "\n" + last + " \n-----END TEST-----\n", mode: Strict},
+			wantBlk:  Block{Type: "TEST", Headers: map[string]string{}},
+			wantLine: 3,
+		},
+		{
+			name:     "StrictBadQuantum",
+			args:     args{in: "-----BEGIN TEST-----\n" + full + "\n" + last[:len(last)-2] + "\n-----END TEST-----\n", mode: Strict},
+			wantBlk:  Block{Type: "TEST", Headers: map[string]string{}},
+			wantLine: 3,
+		},
+		{
+			name:     "StrictPaddingInside",
+			args:     args{in: "-----BEGIN TEST-----\n" + full[:60] + "AA==\n" + full + "\n-----END TEST-----\n", mode: Strict},
+			wantBlk:  Block{Type: "TEST", Headers: map[string]string{}},
+			wantLine: 3,
+		},
+		{
+			name:     "StrictBadLabel",
+			args:     args{in: "-----BEGIN TEST--KEY-----\n" + last + "\n-----END TEST--KEY-----\n", mode: Strict},
+			wantBlk:  Block{Headers: map[string]string{}},
+			wantLine: 1,
+		},
+		{
+			name:     "TypeMismatch",
+			args:     args{in: "-----BEGIN TEST-----\n" + last + "\n-----END TSET-----\n"},
+			wantBlk:  Block{Type: "TEST", Headers: map[string]string{}},
+			wantLine: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, blk := FromPem(strings.NewReader(tt.args.in), WithMode(tt.args.mode))
+			got, err := io.ReadAll(rdr)
+			if tt.wantLine != 0 {
+				var perr *ParseError
+				if !errors.As(err, &perr) || perr.Line != tt.wantLine {
+					t.Errorf("FromPem() error = %v, want a ParseError on line %d", err, tt.wantLine)
+				}
+			} else if err != nil || string(got) != tt.want {
+				t.Errorf("FromPem() = %q, %v, want %q", got, err, tt.want)
+			}
+			if !reflect.DeepEqual(blk, tt.wantBlk) {
+				t.Errorf("FromPem() blk = %#v, want %#v", blk, tt.wantBlk)
+			}
+		})
+	}
+}
@@ -6,13 +6,19 @@
 // binary data.  PEM data encoding originated in Privacy Enhanced Mail. The most
 // common use of PEM encoding today is in TLS keys and certificates.  These filters
 // can be connected to other filters via io.Pipes.
+//
+// FromPem reads a single PEM block.  A Reader reads each of the blocks in a
+// bundle, such as a certificate chain, in turn.
 package pem
 
 import (
-	"bufio"
-	"bytes"
+	stdbase64 "encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/bgallie/filters/base64"
 	"github.com/bgallie/filters/lines"
@@ -28,16 +34,161 @@ import (
 //	-----END Type-----
 //
 // where Headers is a possibly empty sequence of Key: Value lines.
+//
+// ToPem writes the headers in HeaderList in order, followed by those in the
+// Headers map sorted by key.  Headers is authoritative: if it is not nil,
+// the entries in HeaderList for a key that is missing from Headers, or whose
+// last value differs from the one in Headers, are dropped, so that changes
+// made to the map of a parsed block are kept.  In either case, a Proc-Type
+// header is always written first, followed by a DEK-Info header.  FromPem
+// fills in both fields, with HeaderList in source order.
 type Block struct {
-	Type    string            // The type, taken from the preamble (i.e. "RSA PRIVATE KEY").
-	Headers map[string]string // Optional headers.
+	Type       string            // The type, taken from the preamble (i.e. "RSA PRIVATE KEY").
+	Headers    map[string]string // Optional headers.  For repeated keys, the last value is kept.
+	HeaderList []Header          // Optional headers in order.  Keys may be repeated.
+}
+
+// A Header is a single "Key: Value" header of a PEM block.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// AddHeader appends a header to b.HeaderList and records it in b.Headers.
+func (b *Block) AddHeader(key, value string) {
+	if b.Headers == nil {
+		b.Headers = make(map[string]string)
+	}
+	b.Headers[key] = value
+	b.HeaderList = append(b.HeaderList, Header{Key: key, Value: value})
+}
+
+// HeaderValues returns the values of all of the headers with the given key,
+// in order.
+func (b Block) HeaderValues(key string) []string {
+	var values []string
+	if b.current()[key] {
+		for _, h := range b.HeaderList {
+			if h.Key == key {
+				values = append(values, h.Value)
+			}
+		}
+	}
+	if values == nil {
+		if v, ok := b.Headers[key]; ok {
+			values = []string{v}
+		}
+	}
+	return values
+}
+
+// headerRank orders Proc-Type before DEK-Info before any other header.
+func headerRank(key string) int {
+	switch key {
+	case procTypeKey:
+		return 0
+	case dekInfoKey:
+		return 1
+	}
+	return 2
+}
+
+// current returns the keys in b.HeaderList whose entries agree with
+// b.Headers: b.Headers is nil, or holds the last of their values.
+func (b Block) current() map[string]bool {
+	last := make(map[string]string)
+	for _, h := range b.HeaderList {
+		last[h.Key] = h.Value
+	}
+	keys := make(map[string]bool)
+	for k, v := range last {
+		if mv, ok := b.Headers[k]; b.Headers == nil || ok && mv == v {
+			keys[k] = true
+		}
+	}
+	return keys
+}
+
+// orderedHeaders returns the headers of b in the order that ToPem writes
+// them.
+func (b Block) orderedHeaders() []Header {
+	inList := b.current()
+	var hdrs []Header
+	for _, h := range b.HeaderList {
+		if inList[h.Key] {
+			hdrs = append(hdrs, h)
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(b.Headers)) {
+		if !inList[k] {
+			hdrs = append(hdrs, Header{Key: k, Value: b.Headers[k]})
+		}
+	}
+	slices.SortStableFunc(hdrs, func(a, b Header) int {
+		return headerRank(a.Key) - headerRank(b.Key)
+	})
+	return hdrs
+}
+
+// An Option configures the behaviour of ToPem, FromPem and Reader.
+type Option func(*options)
+
+type options struct {
+	cipher         Cipher
+	passphrase     []byte
+	passphraseFunc func() ([]byte, error)
+	mode           Mode
+	lineWidth      int
+}
+
+// DefaultLineWidth is the number of base64 characters per line written by
+// ToPem unless the WithLineWidth option is given.
+const DefaultLineWidth = 76
+
+// newOptions applies opts to the default options.
+func newOptions(opts []Option) options {
+	o := options{lineWidth: DefaultLineWidth}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithEncryption causes ToPem to encrypt the body of the PEM block using
+// cipher c and a key derived from passphrase.  The "Proc-Type: 4,ENCRYPTED"
+// and "DEK-Info" headers (RFC 1421) are written before any other headers.
+func WithEncryption(c Cipher, passphrase []byte) Option {
+	return func(o *options) {
+		o.cipher = c
+		o.passphrase = passphrase
+	}
+}
+
+// WithLineWidth sets the number of base64 characters per line written by
+// ToPem.  Use 64 for OpenSSL style (RFC 7468) output or 76 for MIME style
+// output.
+func WithLineWidth(n int) Option {
+	return func(o *options) {
+		o.lineWidth = n
+	}
+}
+
+// WithPassphrase provides FromPem with a function that returns the
+// passphrase used to decrypt an encrypted PEM block.  It is only called if
+// the block has a "Proc-Type: 4,ENCRYPTED" header.  Without this option, the
+// body of an encrypted block is returned still encrypted.
+func WithPassphrase(passphrase func() ([]byte, error)) Option {
+	return func(o *options) {
+		o.passphraseFunc = passphrase
+	}
 }
 
 // ToPem reads data from r, encodes it using PEM formatted encoding.
 // The 'blk' parameter provides the "Type" and "Headers" in the encoded form.
 // The PEM encoded data can be read using the returned PipeReader.
-func ToPem(r io.Reader, blk Block) *io.PipeReader {
+func ToPem(r io.Reader, blk Block, opts ...Option) *io.PipeReader {
 	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
 
 	go func() {
 		defer rWrtr.Close()
@@ -45,14 +196,33 @@ func ToPem(r io.Reader, blk Block) *io.PipeReader {
 		if err != nil {
 			rWrtr.CloseWithError(fmt.Errorf("failure printing PEM BEGIN line to an io.PipeWriter: %w", err))
 		}
-		for k, v := range blk.Headers {
-			_, err = fmt.Fprintf(rWrtr, "%s: %s\n", k, v)
+		if o.cipher != 0 {
+			if _, ok := cipherInfo[o.cipher]; !ok {
+				rWrtr.CloseWithError(fmt.Errorf("pem: unsupported cipher %v", o.cipher))
+				return
+			}
+			d, err := newDEK(o.cipher)
+			if err != nil {
+				rWrtr.CloseWithError(err)
+				return
+			}
+			_, err = fmt.Fprintf(rWrtr, "%s: %s\n%s: %s\n", procTypeKey, procTypeEncrypted, dekInfoKey, d)
 			if err != nil {
 				rWrtr.CloseWithError(fmt.Errorf("failure printing PEM Header line to an io.PipeWriter: %w", err))
+				return
 			}
+			r = encrypt(r, d, o.passphrase)
 		}
-		lines.LineSize = 76 // output 76 characters per line
-		_, err = io.Copy(rWrtr, lines.SplitToLines(base64.ToBase64(r)))
+		for _, h := range blk.orderedHeaders() {
+			if o.cipher != 0 && (h.Key == procTypeKey || h.Key == dekInfoKey) {
+				continue
+			}
+			_, err = fmt.Fprintf(rWrtr, "%s: %s\n", h.Key, h.Value)
+			if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("failure printing PEM Header line to an io.PipeWriter: %w", err))
+			}
+		}
+		_, err = io.Copy(rWrtr, lines.SplitToLines(base64.ToBase64(r), lines.WithLineSize(o.lineWidth)))
 		if err != nil {
 			rWrtr.CloseWithError(fmt.Errorf("failure copying (io.Copy) base64 encoded data to an io.PipeWriterr: %w", err))
 		}
@@ -67,67 +237,83 @@ func ToPem(r io.Reader, blk Block) *io.PipeReader {
 
 // FromPem reads PEM encoded data from r, decodes it using a base64
 // decoder.  The PEM information is returned in the pem.Block structure
-// and the decoded data can be read using the returned PipeReader.
-func FromPem(r io.Reader) (*io.PipeReader, Block) {
+// and the decoded data can be read using the returned PipeReader.  If the
+// block is encrypted and the WithPassphrase option is given, the data is
+// decrypted as well.  Malformed PEM data is reported by the PipeReader
+// with a *ParseError giving the offending line.
+func FromPem(r io.Reader, opts ...Option) (*io.PipeReader, Block) {
+	o := newOptions(opts)
 	var blk Block
 	blk.Headers = make(map[string]string)
 	base64R, base64W := io.Pipe()
-	bRdr := bufio.NewReader(r)
-	line, err := bRdr.ReadBytes('\n')
+	lr := newLineReader(r)
+	// Get the type of PEM message
+	line, err := lr.readLine()
 	if err != nil {
 		base64W.CloseWithError(fmt.Errorf("missing PEM message: %w", err))
+		return base64.FromBase64(base64R), blk
 	}
-	// Get the type of PEM message
-	if bytes.HasPrefix(line, []byte("-----BEGIN ")) {
-		i := bytes.Index(line, []byte(" ")) + 1
-		j := bytes.Index(line[i:], []byte("-"))
-		blk.Type = string(line[i : i+j])
-	} else {
-		base64W.CloseWithError(fmt.Errorf("incorrectly formed PEM message: no BEGIN line"))
+	typ, ok := parseDelimiter(line, "BEGIN", o.mode)
+	if !ok {
+		base64W.CloseWithError(&ParseError{Line: lr.line, Msg: "incorrectly formed PEM message: no BEGIN line"})
+		return base64.FromBase64(base64R), blk
 	}
+	blk.Type = typ
 	// Get the header data if any.
-	for {
-		line, err = bRdr.ReadBytes('\n')
-		if err != nil {
-			base64W.CloseWithError(fmt.Errorf("incomplete/malformed PEM message: %w", err))
-		}
-		i := bytes.Index(line, []byte(": "))
-		if i < 0 {
-			break
-		}
-		k := string(line[:i])
-		v := string(line[i+2 : len(line)-1])
-		blk.Headers[k] = v
+	line, err = readHeaders(lr, &blk, o.mode)
+	if err != nil {
+		base64W.CloseWithError(err)
+		return base64.FromBase64(base64R), blk
 	}
 	// Process the base64 data and validate the 'END' line.
 	go func() {
 		defer base64W.Close()
-		// Read the base64 data from the PEM message and send it to the base64
-		// filter for decoding after processing any header information.
-		for err == nil && !bytes.HasPrefix(line, []byte("-----END ")) {
-			_, err = base64W.Write(line)
-			if err != nil {
-				base64W.CloseWithError(fmt.Errorf("failed to write to a base64.Encoder: %w", err))
+		bc := bodyChecker{mode: o.mode}
+		padded := false // A strict body line ended with padding.
+		for {
+			if typ, ok := parseDelimiter(line, "END", o.mode); ok {
+				if typ != blk.Type {
+					base64W.CloseWithError(&ParseError{Line: lr.line, Msg: fmt.Sprintf("BEGIN/END type mismatch: %q and %q", blk.Type, typ)})
+				}
+				return
+			}
+			if strings.HasPrefix(strings.TrimSpace(line), "-----") {
+				base64W.CloseWithError(&ParseError{Line: lr.line, Msg: "incorrectly formed PEM message: unexpected delimiter line"})
+				return
 			}
-			line, err = bRdr.ReadBytes('\n')
+			data, err := bc.check(line, lr.line)
 			if err != nil {
-				base64W.CloseWithError(fmt.Errorf("incomplete/malformed PEM message: %w", err))
+				base64W.CloseWithError(err)
+				return
 			}
-		}
-		if err == nil {
-			if bytes.HasPrefix(line, []byte("-----END ")) {
-				i := bytes.Index(line, []byte(" ")) + 1
-				j := bytes.Index(line[i:], []byte("-"))
-				if blk.Type != string(line[i:i+j]) {
-					base64W.CloseWithError(fmt.Errorf("incorrectly formed PEM message: BEGIN/END type mismatch"))
+			// A strict body line holds whole base64 quanta, so it can be
+			// decoded on its own to give the line of any invalid data,
+			// which the base64 filter cannot.
+			if o.mode == Strict {
+				if _, err = stdbase64.StdEncoding.DecodeString(data); err != nil || padded && data != "" {
+					base64W.CloseWithError(&ParseError{Line: lr.line, Msg: fmt.Sprintf("invalid base64 data in %q block", blk.Type)})
+					return
 				}
-			} else {
-				base64W.CloseWithError(fmt.Errorf("incorrectly formed PEM message: missing END line"))
+				padded = strings.HasSuffix(data, "=")
+			}
+			// Send the base64 data to the base64 filter for decoding.
+			if _, err = io.WriteString(base64W, data); err != nil {
+				base64W.CloseWithError(fmt.Errorf("failed to write to a base64.Decoder: %w", err))
+				return
+			}
+			line, err = lr.readLine()
+			if errors.Is(err, io.EOF) {
+				base64W.CloseWithError(&ParseError{Line: lr.line, Msg: "incorrectly formed PEM message: missing END line"})
+				return
+			} else if err != nil {
+				base64W.CloseWithError(fmt.Errorf("incomplete/malformed PEM message: %w", err))
+				return
 			}
-		} else {
-			base64W.CloseWithError(fmt.Errorf("incorrectly formed PEM message."))
 		}
 	}()
 
+	if o.passphraseFunc != nil && blk.Headers[procTypeKey] == procTypeEncrypted {
+		return decrypt(base64.FromBase64(base64R), blk.Headers[dekInfoKey], o.passphraseFunc), blk
+	}
 	return base64.FromBase64(base64R), blk
 }
@@ -1,6 +1,7 @@
 package pem
 
 import (
+	"bytes"
 	"io"
 	"reflect"
 	"strings"
@@ -62,7 +63,7 @@ func TestFromPem(t *testing.T) {
 			name:  "Test One",
 			args:  args{r: strings.NewReader("-----BEGIN Test One-----\nCOUNT: 100\nVGhpcyBpcyBvbmx5IGEgdGVzdA==\n-----END Test One-----\n")},
 			want:  "This is only a test",
-			want1: Block{Type: "Test One", Headers: map[string]string{"COUNT": "100"}},
+			want1: Block{Type: "Test One", Headers: map[string]string{"COUNT": "100"}, HeaderList: []Header{{"COUNT", "100"}}},
 		},
 		{
 			name: "Test Two",
@@ -72,7 +73,7 @@ func TestFromPem(t *testing.T) {
 				"aGUgcXVpY2sgYnJvd24gZm94IGp1bXBlZCBvdmVyIHRoZSBsYXp5IGRvZy4=\n" +
 				"-----END Test Two-----\n")},
 			want:  "The quick brown fox jumped over the lazy dog.  The quick brown fox jumped over the lazy dog.",
-			want1: Block{Type: "Test Two", Headers: map[string]string{"COUNT": "200"}},
+			want1: Block{Type: "Test Two", Headers: map[string]string{"COUNT": "200"}, HeaderList: []Header{{"COUNT", "200"}}},
 		},
 	}
 	for _, tt := range tests {
@@ -88,3 +89,119 @@ func TestFromPem(t *testing.T) {
 		})
 	}
 }
+
+func TestToPemHeaderOrder(t *testing.T) {
+	type args struct {
+		r   io.Reader
+		blk Block
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "SortedMap",
+			args: args{r: strings.NewReader("This is only a test"), blk: Block{Type: "Test", Headers: map[string]string{
+				"Zulu": "1", "Alpha": "2", "Mike": "3", "DEK-Info": "4", "Proc-Type": "5"}}},
+			want: "-----BEGIN Test-----\nProc-Type: 5\nDEK-Info: 4\nAlpha: 2\nMike: 3\nZulu: 1\n" +
+				"VGhpcyBpcyBvbmx5IGEgdGVzdA==\n-----END Test-----\n",
+		},
+		{
+			name: "OrderedList",
+			args: args{r: strings.NewReader("This is only a test"), blk: Block{Type: "Test",
+				HeaderList: []Header{{"Zulu", "1"}, {"Alpha", "2"}, {"Zulu", "3"}, {"Proc-Type", "4"}},
+				Headers:    map[string]string{"Zulu": "3", "Alpha": "2", "Proc-Type": "4", "Bravo": "5"}}},
+			want: "-----BEGIN Test-----\nProc-Type: 4\nZulu: 1\nAlpha: 2\nZulu: 3\nBravo: 5\n" +
+				"VGhpcyBpcyBvbmx5IGEgdGVzdA==\n-----END Test-----\n",
+		},
+		{
+			name: "ListOnly",
+			args: args{r: strings.NewReader("This is only a test"), blk: Block{Type: "Test",
+				HeaderList: []Header{{"Zulu", "1"}, {"Alpha", "2"}, {"Zulu", "3"}}}},
+			want: "-----BEGIN Test-----\nZulu: 1\nAlpha: 2\nZulu: 3\n" +
+				"VGhpcyBpcyBvbmx5IGEgdGVzdA==\n-----END Test-----\n",
+		},
+		{
+			name: "MapChanged",
+			args: args{r: strings.NewReader("This is only a test"), blk: Block{Type: "Test",
+				HeaderList: []Header{{"Zulu", "1"}, {"Alpha", "2"}, {"Zulu", "3"}, {"Bravo", "4"}},
+				Headers:    map[string]string{"Zulu": "changed", "Alpha": "2"}}},
+			want: "-----BEGIN Test-----\nAlpha: 2\nZulu: changed\n" +
+				"VGhpcyBpcyBvbmx5IGEgdGVzdA==\n-----END Test-----\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToPem(tt.args.r, tt.args.blk)); string(got) != tt.want {
+				t.Errorf("ToPem() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestToPemEditedBlock(t *testing.T) {
+	src := "-----BEGIN Test-----\nAlpha: 1\nBravo: 2\nCharlie: 3\nVGhpcyBpcyBvbmx5IGEgdGVzdA==\n-----END Test-----\n"
+	rdr, blk := FromPem(strings.NewReader(src))
+	data, err := io.ReadAll(rdr)
+	if err != nil {
+		t.Fatalf("FromPem() error = %v", err)
+	}
+	blk.Headers["Alpha"] = "changed"
+	delete(blk.Headers, "Bravo")
+	want := "-----BEGIN Test-----\nCharlie: 3\nAlpha: changed\nVGhpcyBpcyBvbmx5IGEgdGVzdA==\n-----END Test-----\n"
+	if got, _ := io.ReadAll(ToPem(bytes.NewReader(data), blk)); string(got) != want {
+		t.Errorf("ToPem() = %q, want %q", got, want)
+	}
+	if v := blk.HeaderValues("Alpha"); !reflect.DeepEqual(v, []string{"changed"}) {
+		t.Errorf("HeaderValues() = %v, want %v", v, []string{"changed"})
+	}
+}
+
+func TestFromPemRepeatedHeaders(t *testing.T) {
+	var want Block
+	want.Type = "Test"
+	want.AddHeader("Zulu", "1")
+	want.AddHeader("Alpha", "2")
+	want.AddHeader("Zulu", "3")
+	rdr, got := FromPem(ToPem(strings.NewReader("This is only a test"), want))
+	io.ReadAll(rdr)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromPem() got = %v, want %v", got, want)
+	}
+	if v := got.HeaderValues("Zulu"); !reflect.DeepEqual(v, []string{"1", "3"}) {
+		t.Errorf("HeaderValues() = %v, want %v", v, []string{"1", "3"})
+	}
+}
+
+func TestToPemLineWidth(t *testing.T) {
+	in := "The quick brown fox jumped over the lazy dog.  The quick brown fox jumped over the lazy dog."
+	want := map[int]string{
+		64: "-----BEGIN Test-----\n" +
+			"VGhlIHF1aWNrIGJyb3duIGZveCBqdW1wZWQgb3ZlciB0aGUgbGF6eSBkb2cuICBU\n" +
+			"aGUgcXVpY2sgYnJvd24gZm94IGp1bXBlZCBvdmVyIHRoZSBsYXp5IGRvZy4=\n" +
+			"-----END Test-----\n",
+		76: "-----BEGIN Test-----\n" +
+			"VGhlIHF1aWNrIGJyb3duIGZveCBqdW1wZWQgb3ZlciB0aGUgbGF6eSBkb2cuICBUaGUgcXVpY2sg\n" +
+			"YnJvd24gZm94IGp1bXBlZCBvdmVyIHRoZSBsYXp5IGRvZy4=\n" +
+			"-----END Test-----\n",
+	}
+	// Run several encodings with different widths at once to make sure
+	// that they do not interfere with each other.
+	type result struct {
+		width int
+		got   string
+	}
+	done := make(chan result)
+	for i := 0; i < 8; i++ {
+		go func(width int) {
+			got, _ := io.ReadAll(ToPem(strings.NewReader(in), Block{Type: "Test"}, WithLineWidth(width)))
+			done <- result{width, string(got)}
+		}(64 + i%2*12)
+	}
+	for i := 0; i < 8; i++ {
+		if r := <-done; r.got != want[r.width] {
+			t.Errorf("ToPem() with width %d = %v, want %v", r.width, r.got, want[r.width])
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pem
+
+import (
+	"bytes"
+	stdbase64 "encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// An Entry is a PEM block read from a bundle by a Reader.
+type Entry struct {
+	Block Block     // The type and headers of the block.
+	Body  io.Reader // The decoded (and, if requested, decrypted) body.
+	Text  []byte    // Any text between the previous block (or the start) and this block.
+	Line  int       // The line number of the BEGIN line.
+}
+
+// A Reader reads a sequence of PEM blocks, such as a certificate chain or a
+// key bundle, from an io.Reader.  Text outside of the blocks is preserved in
+// the Text field of the following Entry, or in Trailer after the last block.
+type Reader struct {
+	lr      *lineReader
+	opts    options
+	err     error
+	trailer []byte
+}
+
+// NewReader returns a Reader that reads PEM blocks from r.  The options are
+// the same as those accepted by FromPem.
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	return &Reader{lr: newLineReader(r), opts: newOptions(opts)}
+}
+
+// Next returns the next block in the bundle.  At the end of the input, it
+// returns io.EOF.  A malformed block is reported with a *ParseError, after
+// which Next always returns the same error.
+func (r *Reader) Next() (*Entry, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	entry, err := r.next()
+	if err != nil {
+		r.err = err
+	}
+	return entry, err
+}
+
+func (r *Reader) next() (*Entry, error) {
+	var text bytes.Buffer
+	var entry Entry
+	// Find the BEGIN line, collecting any text before it.
+	for {
+		line, err := r.lr.readLine()
+		if errors.Is(err, io.EOF) {
+			r.trailer = text.Bytes()
+			return nil, io.EOF
+		} else if err != nil {
+			return nil, fmt.Errorf("pem: error reading from an io.Reader: %w", err)
+		}
+		if typ, ok := parseDelimiter(line, "BEGIN", r.opts.mode); ok {
+			entry.Block.Type = typ
+			entry.Line = r.lr.line
+			break
+		}
+		text.WriteString(line)
+		text.WriteByte('\n')
+	}
+	entry.Text = text.Bytes()
+	entry.Block.Headers = make(map[string]string)
+	line, err := readHeaders(r.lr, &entry.Block, r.opts.mode)
+	if err != nil {
+		return nil, err
+	}
+	// Read the body up to the END line.
+	var body []byte
+	var bodyLines []int // The line number of each byte of body.
+	bc := bodyChecker{mode: r.opts.mode}
+	for {
+		if typ, ok := parseDelimiter(line, "END", r.opts.mode); ok {
+			if typ != entry.Block.Type {
+				return nil, &ParseError{Line: r.lr.line, Msg: fmt.Sprintf("END type %q does not match BEGIN type %q", typ, entry.Block.Type)}
+			}
+			break
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "-----") {
+			return nil, &ParseError{Line: r.lr.line, Msg: "unexpected delimiter line inside a block"}
+		}
+		data, err := bc.check(line, r.lr.line)
+		if err != nil {
+			return nil, err
+		}
+		for range data {
+			bodyLines = append(bodyLines, r.lr.line)
+		}
+		body = append(body, data...)
+		line, err = r.lr.readLine()
+		if errors.Is(err, io.EOF) {
+			return nil, &ParseError{Line: r.lr.line, Msg: fmt.Sprintf("missing END line for %q begun on line %d", entry.Block.Type, entry.Line)}
+		} else if err != nil {
+			return nil, fmt.Errorf("pem: error reading from an io.Reader: %w", err)
+		}
+	}
+	decoded := make([]byte, stdbase64.StdEncoding.DecodedLen(len(body)))
+	n, err := stdbase64.StdEncoding.Decode(decoded, body)
+	if err != nil {
+		var corrupt stdbase64.CorruptInputError
+		line := r.lr.line
+		if errors.As(err, &corrupt) && int(corrupt) < len(bodyLines) {
+			line = bodyLines[corrupt]
+		}
+		return nil, &ParseError{Line: line, Msg: fmt.Sprintf("invalid base64 data in %q block", entry.Block.Type)}
+	}
+	entry.Body = bytes.NewReader(decoded[:n])
+	if r.opts.passphraseFunc != nil && entry.Block.Headers[procTypeKey] == procTypeEncrypted {
+		entry.Body = decrypt(entry.Body, entry.Block.Headers[dekInfoKey], r.opts.passphraseFunc)
+	}
+	return &entry, nil
+}
+
+// All returns an iterator over the remaining blocks in the bundle.  The
+// iteration stops at the end of the input or at the first error, which can
+// be retrieved using Err.
+func (r *Reader) All() iter.Seq[*Entry] {
+	return func(yield func(*Entry) bool) {
+		for {
+			entry, err := r.Next()
+			if err != nil || !yield(entry) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error, other than io.EOF, encountered by Next.
+func (r *Reader) Err() error {
+	if errors.Is(r.err, io.EOF) {
+		return nil
+	}
+	return r.err
+}
+
+// Trailer returns any text that follows the last block.  It is only valid
+// after Next has returned io.EOF.
+func (r *Reader) Trailer() []byte {
+	return r.trailer
+}
@@ -0,0 +1,102 @@
+package pem
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testBundle = "Subject: Test One\n" +
+	"-----BEGIN Test One-----\n" +
+	"COUNT: 100\n" +
+	"VGhpcyBpcyBvbmx5IGEgdGVzdA==\n" +
+	"-----END Test One-----\n" +
+	"Subject: Test Two\n" +
+	"-----BEGIN Test Two-----\n" +
+	"VGhlIHF1aWNrIGJyb3duIGZveCBqdW1wZWQgb3ZlciB0aGUgbGF6eSBkb2cuICBU\n" +
+	"aGUgcXVpY2sgYnJvd24gZm94IGp1bXBlZCBvdmVyIHRoZSBsYXp5IGRvZy4=\n" +
+	"-----END Test Two-----\n" +
+	"The end.\n"
+
+func TestReaderNext(t *testing.T) {
+	type result struct {
+		blk  Block
+		body string
+		text string
+		line int
+	}
+	want := []result{
+		{
+			blk:  Block{Type: "Test One", Headers: map[string]string{"COUNT": "100"}, HeaderList: []Header{{"COUNT", "100"}}},
+			body: "This is only a test",
+			text: "Subject: Test One\n",
+			line: 2,
+		},
+		{
+			blk:  Block{Type: "Test Two", Headers: map[string]string{}},
+			body: "The quick brown fox jumped over the lazy dog.  The quick brown fox jumped over the lazy dog.",
+			text: "Subject: Test Two\n",
+			line: 7,
+		},
+	}
+	rdr := NewReader(strings.NewReader(testBundle))
+	var got []result
+	for entry := range rdr.All() {
+		body, err := io.ReadAll(entry.Body)
+		if err != nil {
+			t.Fatalf("reading the body of %q: %v", entry.Block.Type, err)
+		}
+		got = append(got, result{blk: entry.Block, body: string(body), text: string(entry.Text), line: entry.Line})
+	}
+	if err := rdr.Err(); err != nil {
+		t.Fatalf("Reader.Err() = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reader.All() = %+v, want %+v", got, want)
+	}
+	if string(rdr.Trailer()) != "The end.\n" {
+		t.Errorf("Reader.Trailer() = %q, want %q", rdr.Trailer(), "The end.\n")
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		wantLine int
+	}{
+		{
+			name:     "MissingEnd",
+			in:       "-----BEGIN A-----\nQUJD\n",
+			wantLine: 2,
+		},
+		{
+			name:     "TypeMismatch",
+			in:       "-----BEGIN A-----\nQUJD\n-----END B-----\n",
+			wantLine: 3,
+		},
+		{
+			name:     "BadBase64",
+			in:       "-----BEGIN A-----\nQUJD\nQU*D\n-----END A-----\n",
+			wantLine: 3,
+		},
+		{
+			name:     "SecondBlock",
+			in:       "-----BEGIN A-----\nQUJD\n-----END A-----\n-----BEGIN B-----\nQUJD\n-----END A-----\n",
+			wantLine: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr := NewReader(strings.NewReader(tt.in))
+			for range rdr.All() {
+			}
+			var perr *ParseError
+			if err := rdr.Err(); !errors.As(err, &perr) || perr.Line != tt.wantLine {
+				t.Errorf("Reader.Err() = %v, want a ParseError on line %d", err, tt.wantLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package percent defines filters to encode/decode data using the
+// percent-encoding of RFC 3986, as used in URLs and HTML forms.  The set of
+// characters left unescaped is selectable.  These filters can be connected
+// to other filters via io.Pipes.
+package percent
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// A Set selects the characters that are left unescaped.
+type Set int
+
+const (
+	// Unreserved leaves only the unreserved characters of RFC 3986, the
+	// letters, digits, '-', '.', '_' and '~', unescaped.  The result is safe
+	// in any part of a URL.
+	Unreserved Set = iota
+	// Path also leaves the characters allowed in a path unescaped: the
+	// sub-delimiters "!$&'()*+,;=", ':', '@' and '/'.
+	Path
+	// Query leaves the characters allowed in a query unescaped, except '&',
+	// '=' and '+', which separate or change the meaning of query
+	// parameters, so the result can be used as a parameter name or value.
+	Query
+	// Form is application/x-www-form-urlencoded: only the unreserved
+	// characters are left unescaped, and spaces are written as '+'.  When
+	// decoding, '+' is read as a space.
+	Form
+)
+
+const upperHex = "0123456789ABCDEF"
+
+// CorruptInputError is returned when a '%' is not followed by two
+// hexadecimal digits.  Its value is the offset of the '%'.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "percent: invalid escape at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// An Option configures the behaviour of ToPercent and FromPercent.
+type Option func(*options)
+
+type options struct {
+	set Set
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithSet selects the set of characters left unescaped.  The default is
+// Unreserved.
+func WithSet(s Set) Option {
+	return func(o *options) {
+		o.set = s
+	}
+}
+
+// unescaped reports if c is left unescaped in the set s.
+func (s Set) unescaped(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	switch c {
+	case '-', '.', '_', '~':
+		return true
+	case '!', '$', '\'', '(', ')', '*', ',', ';', ':', '@', '/':
+		return s == Path || s == Query
+	case '&', '=', '+':
+		return s == Path
+	case '?':
+		return s == Query
+	}
+	return false
+}
+
+// ToPercent reads data from r, percent-encodes it.  The percent-encoded data
+// can be read using the returned PipeReader.
+func ToPercent(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		br := bufio.NewReader(r)
+		bw := bufio.NewWriter(rWrtr)
+		for {
+			c, err := br.ReadByte()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				bw.Flush()
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			switch {
+			case o.set.unescaped(c):
+				bw.WriteByte(c)
+			case o.set == Form && c == ' ':
+				bw.WriteByte('+')
+			default:
+				bw.Write([]byte{'%', upperHex[c>>4], upperHex[c&0x0f]})
+			}
+		}
+		if err := bw.Flush(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+func unhex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// FromPercent reads percent-encoded data from r, decodes it.  Escapes may use
+// upper or lower case hexadecimal digits.  The decoded data can be read using
+// the returned PipeReader.
+func FromPercent(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		br := bufio.NewReader(r)
+		bw := bufio.NewWriter(rWrtr)
+		for offset := int64(0); ; offset++ {
+			c, err := br.ReadByte()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				bw.Flush()
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			switch {
+			case c == '%':
+				var hi, lo byte
+				var ok bool
+				d, err := br.Peek(2)
+				if err == nil {
+					if hi, ok = unhex(d[0]); ok {
+						lo, ok = unhex(d[1])
+					}
+				}
+				if !ok {
+					bw.Flush()
+					rWrtr.CloseWithError(CorruptInputError(offset))
+					return
+				}
+				br.Discard(2)
+				offset += 2
+				c = hi<<4 | lo
+			case c == '+' && o.set == Form:
+				c = ' '
+			}
+			bw.WriteByte(c)
+		}
+		if err := bw.Flush(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
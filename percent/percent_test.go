@@ -0,0 +1,102 @@
+package percent
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const testText = "a/b c?d=e&f+g~h@ü"
+
+func TestToPercent(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testText)},
+			want: "a%2Fb%20c%3Fd%3De%26f%2Bg~h%40%C3%BC",
+		},
+		{
+			name: "Path",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithSet(Path)}},
+			want: "a/b%20c%3Fd=e&f+g~h@%C3%BC",
+		},
+		{
+			name: "Query",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithSet(Query)}},
+			want: "a/b%20c?d%3De%26f%2Bg~h@%C3%BC",
+		},
+		{
+			name: "Form",
+			args: args{r: strings.NewReader(testText), opts: []Option{WithSet(Form)}},
+			want: "a%2Fb+c%3Fd%3De%26f%2Bg~h%40%C3%BC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToPercent(tt.args.r, tt.args.opts...)); string(got) != tt.want {
+				t.Errorf("ToPercent() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromPercent(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("a%2Fb%20c%3Fd%3De%26f%2Bg~h%40%C3%BC")},
+			want: testText,
+		},
+		{
+			name: "LowerCase",
+			args: args{r: strings.NewReader("a%2fb%20c+d%c3%bc")},
+			want: "a/b c+dü",
+		},
+		{
+			name: "Form",
+			args: args{r: strings.NewReader("a%2Fb+c%3Fd%3De%26f%2Bg~h%40%C3%BC"), opts: []Option{WithSet(Form)}},
+			want: testText,
+		},
+		{
+			name:    "BadEscape",
+			args:    args{r: strings.NewReader("a%2Fb%2xc")},
+			want:    "a/b",
+			wantErr: CorruptInputError(5),
+		},
+		{
+			name:    "ShortEscape",
+			args:    args{r: strings.NewReader("a%2Fb%2")},
+			want:    "a/b",
+			wantErr: CorruptInputError(5),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromPercent(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromPercent() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromPercent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/quotedprintable
+
+go 1.24.2
@@ -0,0 +1,199 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package quotedprintable defines filters to encode/decode data using the
+// quoted-printable encoding of RFC 2045, which leaves printable ASCII
+// readable.  Decoding is done by mime/quotedprintable; encoding follows
+// mime/quotedprintable.Writer, but allows the line length to be set.  These
+// filters can be connected to other filters via io.Pipes.
+package quotedprintable
+
+import (
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+)
+
+// DefaultLineLength is the maximum line length allowed by RFC 2045, not
+// counting the CRLF.
+const DefaultLineLength = 76
+
+const upperHex = "0123456789ABCDEF"
+
+// An Option configures the behaviour of ToQuotedPrintable.
+type Option func(*options)
+
+type options struct {
+	binary     bool
+	lineLength int
+}
+
+// WithBinary treats the data as binary: line breaks in the data are
+// encoded, as "=0D" and "=0A", instead of being written as CRLFs.
+func WithBinary() Option {
+	return func(o *options) {
+		o.binary = true
+	}
+}
+
+// WithLineLength sets the maximum length of the encoded lines, not counting
+// the CRLF.  It must be at least 4, and should not be more than
+// DefaultLineLength.
+func WithLineLength(n int) Option {
+	return func(o *options) {
+		o.lineLength = n
+	}
+}
+
+// encoder encodes data written to it in quoted-printable, in the same way
+// as mime/quotedprintable.Writer.
+type encoder struct {
+	w          io.Writer
+	binary     bool
+	lineLength int
+	line       []byte
+	cr         bool
+}
+
+func isWhitespace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	for _, b := range p {
+		var err error
+		switch {
+		case b >= '!' && b <= '~' && b != '=' || isWhitespace(b):
+			err = e.write(b)
+		case !e.binary && (b == '\n' || b == '\r'):
+			err = e.lineBreak(b)
+		default:
+			err = e.encode(b)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// write writes b as it is.
+func (e *encoder) write(b byte) error {
+	if len(e.line) == e.lineLength-1 {
+		if err := e.insertSoftLineBreak(); err != nil {
+			return err
+		}
+	}
+	e.line = append(e.line, b)
+	e.cr = false
+	return nil
+}
+
+// encode writes b as "=XX".
+func (e *encoder) encode(b byte) error {
+	if e.lineLength-1-len(e.line) < 3 {
+		if err := e.insertSoftLineBreak(); err != nil {
+			return err
+		}
+	}
+	e.line = append(e.line, '=', upperHex[b>>4], upperHex[b&0x0f])
+	return nil
+}
+
+// lineBreak writes a hard line break for a CR, an LF or a CRLF in the data.
+func (e *encoder) lineBreak(b byte) error {
+	// If the previous byte was \r, the CRLF has already been inserted.
+	if e.cr && b == '\n' {
+		e.cr = false
+		return nil
+	}
+	e.cr = b == '\r'
+	if err := e.checkLastByte(); err != nil {
+		return err
+	}
+	return e.insertCRLF()
+}
+
+// checkLastByte encodes white space at the end of the line, since decoders
+// remove it.
+func (e *encoder) checkLastByte() error {
+	if n := len(e.line); n > 0 && isWhitespace(e.line[n-1]) {
+		b := e.line[n-1]
+		e.line = e.line[:n-1]
+		return e.encode(b)
+	}
+	return nil
+}
+
+func (e *encoder) insertSoftLineBreak() error {
+	e.line = append(e.line, '=')
+	return e.insertCRLF()
+}
+
+func (e *encoder) insertCRLF() error {
+	e.line = append(e.line, '\r', '\n')
+	return e.flush()
+}
+
+func (e *encoder) flush() error {
+	_, err := e.w.Write(e.line)
+	e.line = e.line[:0]
+	return err
+}
+
+// Close encodes trailing white space and writes the last line.
+func (e *encoder) Close() error {
+	if err := e.checkLastByte(); err != nil {
+		return err
+	}
+	return e.flush()
+}
+
+// ToQuotedPrintable reads data from r, encodes it using quoted-printable.
+// The quoted-printable encoded data can be read using the returned
+// PipeReader.
+func ToQuotedPrintable(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := options{lineLength: DefaultLineLength}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.lineLength < 4 {
+		rWrtr.CloseWithError(fmt.Errorf("quotedprintable: invalid line length: %d", o.lineLength))
+		return rRdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		enc := &encoder{w: rWrtr, binary: o.binary, lineLength: o.lineLength}
+		_, err := io.Copy(enc, r)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an io.Reader to a quoted-printable encoder: %w", err))
+			return
+		}
+		if err = enc.Close(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// FromQuotedPrintable reads quoted-printable encoded data from r, decodes it
+// using the mime/quotedprintable reader.  The decoded data can be read using
+// the returned PipeReader.
+func FromQuotedPrintable(r io.Reader) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	qpR := quotedprintable.NewReader(r)
+
+	go func() {
+		defer rWrtr.Close()
+		_, err := io.Copy(rWrtr, qpR)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from a quotedprintable.Reader to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
@@ -0,0 +1,108 @@
+package quotedprintable
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+const (
+	testText    = "Café au lait = 3€ \nsecond line\t\r\nthird"
+	testEncoded = "Caf=C3=A9 au lait =3D 3=E2=82=AC=20\r\nsecond line=09\r\nthird"
+)
+
+func TestToQuotedPrintable(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testText)},
+			want: testEncoded,
+		},
+		{
+			name: "Binary",
+			args: args{r: strings.NewReader("\x00\xff binary\r\n")},
+			want: "=00=FF binary\r\n",
+		},
+		{
+			name: "BinaryMode",
+			args: args{r: strings.NewReader("\x00\xff binary\r\n"), opts: []Option{WithBinary()}},
+			want: "=00=FF binary=0D=0A",
+		},
+		{
+			name: "SoftLineBreak",
+			args: args{r: strings.NewReader(strings.Repeat("abcdefghij", 8))},
+			want: strings.Repeat("abcdefghij", 7) + "abcde=\r\nfghij",
+		},
+		{
+			name: "LineLength",
+			args: args{r: strings.NewReader(strings.Repeat("abcdefghij", 3) + "=x"), opts: []Option{WithLineLength(20)}},
+			want: "abcdefghijabcdefghi=\r\njabcdefghij=3Dx",
+		},
+		{
+			name:    "BadLineLength",
+			args:    args{r: strings.NewReader(testText), opts: []Option{WithLineLength(3)}},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(ToQuotedPrintable(tt.args.r, tt.args.opts...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToQuotedPrintable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToQuotedPrintable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromQuotedPrintable(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testEncoded)},
+			want: "Café au lait = 3€ \r\nsecond line\t\r\nthird",
+		},
+		{
+			name: "SoftLineBreak",
+			args: args{r: strings.NewReader("abcdefghijabcdefghi=\r\njabcdefghij=3Dx")},
+			want: strings.Repeat("abcdefghij", 3) + "=x",
+		},
+		{
+			name:    "InvalidByte",
+			args:    args{r: strings.NewReader("abc\x01def")},
+			want:    "abc",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromQuotedPrintable(tt.args.r))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromQuotedPrintable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromQuotedPrintable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
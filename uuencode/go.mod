@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/uuencode
+
+go 1.24.2
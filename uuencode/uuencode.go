@@ -0,0 +1,302 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uuencode defines filters to encode/decode data using uuencode, as
+// written by uuencode(1), framed by a "begin <mode> <name>" line and an
+// "end" line.  The xxencode alphabet and the "begin-base64" variant are also
+// supported.  These filters can be connected to other filters via io.Pipes.
+package uuencode
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// A Variant selects the encoding of the lines between the begin and end
+// lines.
+type Variant int
+
+const (
+	// UU is the traditional uuencode encoding.  Each line starts with its
+	// length, and each character encodes 6 bits as a character from ' '
+	// to '_', with '`' used in place of ' '.
+	UU Variant = iota
+	// XX is xxencode, which uses the same line layout as UU with an
+	// alphabet of letters, digits, '+' and '-' that survives EBCDIC
+	// translation.
+	XX
+	// Base64 is the "begin-base64" variant written by GNU uuencode -m,
+	// which holds base64 lines and ends with a "====" line.
+	Base64
+)
+
+const (
+	xxAlphabet = "+-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// lineBytes is the number of bytes encoded on each full line.
+	lineBytes = 45
+)
+
+// ErrFormat is returned when the encoded data is missing its begin or end
+// line, or holds a malformed line.
+var ErrFormat = errors.New("uuencode: malformed data")
+
+// A Header holds the values of the begin line.
+type Header struct {
+	Name    string      // The name of the file.
+	Mode    fs.FileMode // The permission bits of the file.
+	Variant Variant     // The encoding of the data.
+}
+
+// An Option configures the behaviour of ToUU.
+type Option func(*options)
+
+type options struct {
+	variant Variant
+}
+
+// WithVariant selects the encoding used by ToUU.  The default is UU.
+func WithVariant(v Variant) Option {
+	return func(o *options) {
+		o.variant = v
+	}
+}
+
+// encodeChar returns the character encoding the 6 bit value v.
+func encodeChar(v byte, variant Variant) byte {
+	if variant == XX {
+		return xxAlphabet[v&0x3f]
+	}
+	if v&0x3f == 0 {
+		return '`'
+	}
+	return ' ' + v&0x3f
+}
+
+// encodeLine returns the line encoding data, without its line ending.
+func encodeLine(data []byte, variant Variant) []byte {
+	if variant == Base64 {
+		return []byte(base64.StdEncoding.EncodeToString(data))
+	}
+	line := []byte{encodeChar(byte(len(data)), variant)}
+	for i := 0; i < len(data); i += 3 {
+		var g [3]byte
+		copy(g[:], data[i:])
+		line = append(line,
+			encodeChar(g[0]>>2, variant),
+			encodeChar(g[0]<<4|g[1]>>4, variant),
+			encodeChar(g[1]<<2|g[2]>>6, variant),
+			encodeChar(g[2], variant))
+	}
+	return line
+}
+
+// ToUU reads data from r, encodes it using uuencode, framed by a begin line
+// giving name and the permission bits of mode, and an end line.
+// The uuencoded data can be read using the returned PipeReader.
+func ToUU(r io.Reader, name string, mode fs.FileMode, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		bw := bufio.NewWriter(rWrtr)
+		begin, end := "begin", "end"
+		if o.variant == Base64 {
+			begin, end = "begin-base64", "===="
+		}
+		fmt.Fprintf(bw, "%s %03o %s\n", begin, mode.Perm(), name)
+		buf := make([]byte, lineBytes)
+		for {
+			n, err := io.ReadFull(r, buf)
+			if n > 0 {
+				bw.Write(encodeLine(buf[:n], o.variant))
+				bw.WriteByte('\n')
+			}
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			} else if err != nil {
+				bw.Flush()
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+		}
+		if o.variant != Base64 {
+			bw.Write(encodeLine(nil, o.variant))
+			bw.WriteByte('\n')
+		}
+		fmt.Fprintln(bw, end)
+		if err := bw.Flush(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// decodeChar returns the 6 bit value encoded by c, or false if c is not
+// valid in variant.
+func decodeChar(c byte, variant Variant) (byte, bool) {
+	if variant == XX {
+		i := strings.IndexByte(xxAlphabet, c)
+		return byte(i), i >= 0
+	}
+	if c < ' ' || c > '`' {
+		return 0, false
+	}
+	return (c - ' ') & 0x3f, true
+}
+
+// decodeLine returns the data encoded by line.
+func decodeLine(line string, variant Variant) ([]byte, error) {
+	if variant == Base64 {
+		return base64.StdEncoding.DecodeString(line)
+	}
+	if line == "" {
+		return nil, nil
+	}
+	n, ok := decodeChar(line[0], variant)
+	if !ok {
+		return nil, fmt.Errorf("illegal length character %q", line[0])
+	}
+	need := (int(n) + 2) / 3 * 4
+	chars := line[1:]
+	if len(chars) < need {
+		if variant == XX {
+			return nil, fmt.Errorf("line too short for %d bytes", n)
+		}
+		// Some encoders remove trailing spaces.
+		chars += strings.Repeat(" ", need-len(chars))
+	}
+	data := make([]byte, 0, need/4*3)
+	for i := 0; i < need; i += 4 {
+		var g [4]byte
+		for j := range g {
+			v, ok := decodeChar(chars[i+j], variant)
+			if !ok {
+				return nil, fmt.Errorf("illegal character %q", chars[i+j])
+			}
+			g[j] = v
+		}
+		data = append(data, g[0]<<2|g[1]>>4, g[1]<<4|g[2]>>2, g[2]<<6|g[3])
+	}
+	return data[:n], nil
+}
+
+// detect reports whether the first data line, line, is in the UU or XX
+// encoding, by checking which gives a line length that matches its length
+// character.
+func detect(line string) Variant {
+	if line == "" {
+		return UU
+	}
+	fits := func(v Variant) bool {
+		n, ok := decodeChar(line[0], v)
+		return ok && len(line) == 1+(int(n)+2)/3*4
+	}
+	if !fits(UU) && fits(XX) {
+		return XX
+	}
+	return UU
+}
+
+// lineReader reads lines, without their line endings, and counts them.
+type lineReader struct {
+	br   *bufio.Reader
+	line int
+}
+
+func (lr *lineReader) readLine() (string, error) {
+	line, err := lr.br.ReadString('\n')
+	if errors.Is(err, io.EOF) && len(line) > 0 {
+		err = nil
+	}
+	lr.line++
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// readBegin skips any lines before the begin line and parses it.
+func readBegin(lr *lineReader) (Header, error) {
+	var hdr Header
+	for {
+		line, err := lr.readLine()
+		if err != nil {
+			return hdr, fmt.Errorf("%w: missing begin line: %w", ErrFormat, err)
+		}
+		var rest string
+		switch {
+		case strings.HasPrefix(line, "begin "):
+			rest, hdr.Variant = line[len("begin "):], UU
+		case strings.HasPrefix(line, "begin-base64 "):
+			rest, hdr.Variant = line[len("begin-base64 "):], Base64
+		default:
+			continue
+		}
+		mode, name, ok := strings.Cut(rest, " ")
+		perm, err := strconv.ParseUint(mode, 8, 32)
+		if !ok || err != nil || name == "" {
+			return hdr, fmt.Errorf("%w: line %d: malformed begin line", ErrFormat, lr.line)
+		}
+		hdr.Mode, hdr.Name = fs.FileMode(perm).Perm(), name
+		return hdr, nil
+	}
+}
+
+// FromUU reads uuencoded data from r, decodes it.  Any lines before the
+// begin line are skipped, and the variant is detected from the begin line
+// and the first line of data.  The decoded data can be read using the
+// returned PipeReader, and the values of the begin line are returned in a
+// Header.
+func FromUU(r io.Reader) (*io.PipeReader, Header) {
+	rRdr, rWrtr := io.Pipe()
+	lr := &lineReader{br: bufio.NewReader(r)}
+	hdr, err := readBegin(lr)
+	if err != nil {
+		rWrtr.CloseWithError(err)
+		return rRdr, hdr
+	}
+	line, err := lr.readLine()
+	if hdr.Variant == UU {
+		hdr.Variant = detect(line)
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		end := "end"
+		if hdr.Variant == Base64 {
+			end = "===="
+		}
+		for ; ; line, err = lr.readLine() {
+			if errors.Is(err, io.EOF) {
+				rWrtr.CloseWithError(fmt.Errorf("%w: missing %q line", ErrFormat, end))
+				return
+			} else if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			if line == end {
+				return
+			}
+			data, err := decodeLine(line, hdr.Variant)
+			if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("%w: line %d: %v", ErrFormat, lr.line, err))
+				return
+			}
+			if _, err = rWrtr.Write(data); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+		}
+	}()
+
+	return rRdr, hdr
+}
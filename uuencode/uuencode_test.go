@@ -0,0 +1,138 @@
+package uuencode
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testText = "This is only a test of the ToUU filter.\n"
+	testUU   = "begin 644 test file.txt\n" +
+		"H5&AI<R!I<R!O;FQY(&$@=&5S=\"!O9B!T:&4@5&]552!F:6QT97(N\"@``\n" +
+		"`\n" +
+		"end\n"
+	testXX = "begin 600 test.txt\n" +
+		"cJ4VdQm-dQm-jPalt642UR4JnR0-jNW-oO4IUJ4xJJG-aOKloNL6i0U++\n" +
+		"+\n" +
+		"end\n"
+	testBase64 = "begin-base64 755 test.sh\n" +
+		"VGhpcyBpcyBvbmx5IGEgdGVzdCBvZiB0aGUgVG9VVSBmaWx0ZXIuCg==\n" +
+		"====\n"
+)
+
+func TestToUU(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		name string
+		mode fs.FileMode
+		opts []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testText), name: "test file.txt", mode: 0o644},
+			want: testUU,
+		},
+		{
+			name: "XX",
+			args: args{r: strings.NewReader(testText), name: "test.txt", mode: 0o600, opts: []Option{WithVariant(XX)}},
+			want: testXX,
+		},
+		{
+			name: "Base64",
+			args: args{r: strings.NewReader(testText), name: "test.sh", mode: 0o755 | fs.ModeDir, opts: []Option{WithVariant(Base64)}},
+			want: testBase64,
+		},
+		{
+			name: "FullLine",
+			args: args{r: strings.NewReader(strings.Repeat("\x00", 46)), name: "zeros", mode: 0o644},
+			want: "begin 644 zeros\nM" + strings.Repeat("`", 60) + "\n!````\n`\nend\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := io.ReadAll(ToUU(tt.args.r, tt.args.name, tt.args.mode, tt.args.opts...)); string(got) != tt.want {
+				t.Errorf("ToUU() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromUU(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		want1   Header
+		wantErr error
+	}{
+		{
+			name:  "TestOne",
+			args:  args{r: strings.NewReader("From: someone\n\n" + testUU)},
+			want:  testText,
+			want1: Header{Name: "test file.txt", Mode: 0o644, Variant: UU},
+		},
+		{
+			name:  "SpacesForZeros",
+			args:  args{r: strings.NewReader("begin 644 zeros\n!\n \nend\n")},
+			want:  "\x00",
+			want1: Header{Name: "zeros", Mode: 0o644, Variant: UU},
+		},
+		{
+			name:  "XX",
+			args:  args{r: strings.NewReader(strings.ReplaceAll(testXX, "\n", "\r\n"))},
+			want:  testText,
+			want1: Header{Name: "test.txt", Mode: 0o600, Variant: XX},
+		},
+		{
+			name:  "Base64",
+			args:  args{r: strings.NewReader(testBase64)},
+			want:  testText,
+			want1: Header{Name: "test.sh", Mode: 0o755, Variant: Base64},
+		},
+		{
+			name:    "MissingEnd",
+			args:    args{r: strings.NewReader(strings.TrimSuffix(testUU, "end\n"))},
+			want:    testText,
+			want1:   Header{Name: "test file.txt", Mode: 0o644, Variant: UU},
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "IllegalCharacter",
+			args:    args{r: strings.NewReader("begin 644 bad\n#abcd\n`\nend\n")},
+			want1:   Header{Name: "bad", Mode: 0o644, Variant: UU},
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "MissingBegin",
+			args:    args{r: strings.NewReader("This is not uuencoded.\n")},
+			wantErr: ErrFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, got1 := FromUU(tt.args.r)
+			got, err := io.ReadAll(rdr)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromUU() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromUU() got = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("FromUU() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package x25519 defines filters to encrypt/decrypt a stream of data to one
+// or more recipients' X25519 public keys.  A random file key is wrapped for
+// each recipient using crypto/ecdh and the data is encrypted with the file
+// key using the chunked AES-GCM construction of the aead package.  These
+// filters can be connected to other filters via io.Pipes.
+//
+// The encrypted stream (version 1) has the form:
+//
+//	magic "AX25" (4 bytes)
+//	version (1 byte, 0x01)
+//	number of recipients (1 byte)
+//	recipient stanza...
+//	header MAC (32 bytes)
+//	encrypted stream (see aead.Encrypt)
+//
+// where each recipient stanza is:
+//
+//	ephemeral X25519 public key (32 bytes)
+//	wrapped file key (48 bytes)
+//
+// The wrapping key for a stanza is derived with HKDF-SHA256 from the X25519
+// shared secret, using the ephemeral and recipient public keys as the salt.
+// The file key is wrapped with AES-256-GCM under the wrapping key and a zero
+// nonce.  The header MAC is an HMAC-SHA256 over the preceding header, keyed
+// with a key derived from the file key.
+package x25519
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bgallie/filters/aead"
+	"github.com/bgallie/filters/pem"
+)
+
+const (
+	// Version is the version of the encrypted stream format.
+	Version = 1
+	// MaxRecipients is the maximum number of recipients of a stream.
+	MaxRecipients = 255
+	// PublicKeyType is the PEM type used for serialized public keys.
+	PublicKeyType = "PUBLIC KEY"
+	// PrivateKeyType is the PEM type used for serialized private keys.
+	PrivateKeyType = "PRIVATE KEY"
+
+	magic       = "AX25"
+	fileKeySize = 32
+	keySize     = 32
+	wrappedSize = fileKeySize + 16
+	stanzaSize  = keySize + wrappedSize
+	macSize     = sha256.Size
+	wrapInfo    = "filters/x25519 file key"
+	macInfo     = "filters/x25519 header"
+)
+
+var (
+	// ErrNoMatchingKey is returned when the private key does not match any
+	// of the recipients of the stream.
+	ErrNoMatchingKey = errors.New("x25519: no recipient matches the private key")
+	// ErrFormat is returned when the stream does not start with a valid header.
+	ErrFormat = errors.New("x25519: not an encrypted stream")
+	// ErrAuthentication is returned when the header fails verification.
+	ErrAuthentication = errors.New("x25519: header authentication failed")
+)
+
+// GenerateKey generates a new X25519 private key.
+func GenerateKey() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(rand.Reader)
+}
+
+// wrapCipher returns the AES-GCM cipher used to wrap the file key for the
+// recipient public key recipient, given the ephemeral public key ephemeral
+// and their shared secret.
+func wrapCipher(secret, ephemeral, recipient []byte) (cipher.AEAD, error) {
+	salt := append(bytes.Clone(ephemeral), recipient...)
+	key, err := hkdf.Key(sha256.New, secret, salt, wrapInfo, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// headerMAC computes the MAC over the header hdr using the file key.
+func headerMAC(fileKey, hdr []byte) ([]byte, error) {
+	key, err := hkdf.Key(sha256.New, fileKey, nil, macInfo, keySize)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(hdr)
+	return mac.Sum(nil), nil
+}
+
+// buildHeader generates a random file key and returns it along with the
+// header that wraps it for each of the recipients.
+func buildHeader(recipients []*ecdh.PublicKey) ([]byte, []byte, error) {
+	if len(recipients) == 0 || len(recipients) > MaxRecipients {
+		return nil, nil, fmt.Errorf("x25519: the number of recipients must be between 1 and %d", MaxRecipients)
+	}
+	fileKey := make([]byte, fileKeySize)
+	if _, err := rand.Read(fileKey); err != nil {
+		return nil, nil, fmt.Errorf("error generating a file key: %w", err)
+	}
+	var hdr bytes.Buffer
+	hdr.WriteString(magic)
+	hdr.WriteByte(Version)
+	hdr.WriteByte(byte(len(recipients)))
+	nonce := make([]byte, 12)
+	for i, recipient := range recipients {
+		if recipient == nil || recipient.Curve() != ecdh.X25519() {
+			return nil, nil, fmt.Errorf("x25519: recipient %d is not an X25519 public key", i)
+		}
+		ephemeral, err := GenerateKey()
+		if err != nil {
+			return nil, nil, fmt.Errorf("error generating an ephemeral key: %w", err)
+		}
+		secret, err := ephemeral.ECDH(recipient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("x25519: recipient %d: %w", i, err)
+		}
+		gcm, err := wrapCipher(secret, ephemeral.PublicKey().Bytes(), recipient.Bytes())
+		if err != nil {
+			return nil, nil, fmt.Errorf("x25519: recipient %d: %w", i, err)
+		}
+		hdr.Write(ephemeral.PublicKey().Bytes())
+		hdr.Write(gcm.Seal(nil, nonce, fileKey, nil))
+	}
+	mac, err := headerMAC(fileKey, hdr.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	hdr.Write(mac)
+	return fileKey, hdr.Bytes(), nil
+}
+
+// Encrypt reads data from r and encrypts it so that it can be decrypted by
+// the private key of any one of recipients.  The encrypted data can be read
+// using the returned PipeReader.
+func Encrypt(r io.Reader, recipients ...*ecdh.PublicKey) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		fileKey, hdr, err := buildHeader(recipients)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		if _, err = rWrtr.Write(hdr); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing the header to an io.PipeWriter: %w", err))
+			return
+		}
+		if _, err = io.Copy(rWrtr, aead.Encrypt(r, fileKey)); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an aead.Encrypt filter to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// readHeader reads the header from r and unwraps the file key using
+// identity.
+func readHeader(r io.Reader, identity *ecdh.PrivateKey) ([]byte, error) {
+	if identity == nil || identity.Curve() != ecdh.X25519() {
+		return nil, fmt.Errorf("x25519: the private key is not an X25519 private key")
+	}
+	fixed := make([]byte, len(magic)+2)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, fmt.Errorf("error reading the header: %w", ErrFormat)
+	}
+	if string(fixed[:len(magic)]) != magic {
+		return nil, ErrFormat
+	}
+	if v := fixed[len(magic)]; v != Version {
+		return nil, fmt.Errorf("x25519: unsupported version %d", v)
+	}
+	count := int(fixed[len(magic)+1])
+	if count == 0 {
+		return nil, fmt.Errorf("x25519: the stream has no recipients: %w", ErrFormat)
+	}
+	stanzas := make([]byte, count*stanzaSize+macSize)
+	if _, err := io.ReadFull(r, stanzas); err != nil {
+		return nil, fmt.Errorf("error reading the header: %w", ErrFormat)
+	}
+	hdr := append(fixed, stanzas[:count*stanzaSize]...)
+	mac := stanzas[count*stanzaSize:]
+	ourKey := identity.PublicKey().Bytes()
+	nonce := make([]byte, 12)
+	for i := 0; i < count; i++ {
+		stanza := stanzas[i*stanzaSize : (i+1)*stanzaSize]
+		ephemeral, err := ecdh.X25519().NewPublicKey(stanza[:keySize])
+		if err != nil {
+			continue
+		}
+		secret, err := identity.ECDH(ephemeral)
+		if err != nil {
+			continue
+		}
+		gcm, err := wrapCipher(secret, stanza[:keySize], ourKey)
+		if err != nil {
+			return nil, err
+		}
+		fileKey, err := gcm.Open(nil, nonce, stanza[keySize:], nil)
+		if err != nil {
+			continue
+		}
+		want, err := headerMAC(fileKey, hdr)
+		if err != nil {
+			return nil, err
+		}
+		if !hmac.Equal(mac, want) {
+			return nil, ErrAuthentication
+		}
+		return fileKey, nil
+	}
+	return nil, ErrNoMatchingKey
+}
+
+// Decrypt reads data encrypted by Encrypt from r and decrypts it using
+// identity, which must be the private key of one of the recipients.  The
+// decrypted data can be read using the returned PipeReader.
+func Decrypt(r io.Reader, identity *ecdh.PrivateKey) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+
+	go func() {
+		defer rWrtr.Close()
+		fileKey, err := readHeader(r, identity)
+		if err != nil {
+			rWrtr.CloseWithError(err)
+			return
+		}
+		if _, err = io.Copy(rWrtr, aead.Decrypt(r, fileKey)); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an aead.Decrypt filter to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// PublicKeyToPem encodes pub as a PKIX public key in a PEM block of type
+// PublicKeyType.  The PEM encoded key can be read using the returned
+// PipeReader.
+func PublicKeyToPem(pub *ecdh.PublicKey) *io.PipeReader {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		rRdr, rWrtr := io.Pipe()
+		rWrtr.CloseWithError(fmt.Errorf("x25519: error marshaling the public key: %w", err))
+		return rRdr
+	}
+	return pem.ToPem(bytes.NewReader(der), pem.Block{Type: PublicKeyType})
+}
+
+// PrivateKeyToPem encodes priv as a PKCS #8 private key in a PEM block of
+// type PrivateKeyType.  The PEM encoded key can be read using the returned
+// PipeReader.
+func PrivateKeyToPem(priv *ecdh.PrivateKey) *io.PipeReader {
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		rRdr, rWrtr := io.Pipe()
+		rWrtr.CloseWithError(fmt.Errorf("x25519: error marshaling the private key: %w", err))
+		return rRdr
+	}
+	return pem.ToPem(bytes.NewReader(der), pem.Block{Type: PrivateKeyType})
+}
+
+// readPem reads a PEM block of type typ from r and returns its contents.
+func readPem(r io.Reader, typ string) ([]byte, error) {
+	rdr, blk := pem.FromPem(r)
+	der, err := io.ReadAll(rdr)
+	if err != nil {
+		return nil, fmt.Errorf("x25519: error reading a PEM block: %w", err)
+	}
+	if blk.Type != typ {
+		return nil, fmt.Errorf("x25519: unexpected PEM type %q, want %q", blk.Type, typ)
+	}
+	return der, nil
+}
+
+// PublicKeyFromPem reads a PEM encoded public key written by PublicKeyToPem
+// from r.
+func PublicKeyFromPem(r io.Reader) (*ecdh.PublicKey, error) {
+	der, err := readPem(r, PublicKeyType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("x25519: error parsing the public key: %w", err)
+	}
+	pub, ok := key.(*ecdh.PublicKey)
+	if !ok || pub.Curve() != ecdh.X25519() {
+		return nil, fmt.Errorf("x25519: the PEM block does not hold an X25519 public key")
+	}
+	return pub, nil
+}
+
+// PrivateKeyFromPem reads a PEM encoded private key written by
+// PrivateKeyToPem from r.
+func PrivateKeyFromPem(r io.Reader) (*ecdh.PrivateKey, error) {
+	der, err := readPem(r, PrivateKeyType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("x25519: error parsing the private key: %w", err)
+	}
+	priv, ok := key.(*ecdh.PrivateKey)
+	if !ok || priv.Curve() != ecdh.X25519() {
+		return nil, fmt.Errorf("x25519: the PEM block does not hold an X25519 private key")
+	}
+	return priv, nil
+}
@@ -0,0 +1,81 @@
+package x25519
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func mustGenerateKey(t *testing.T) *ecdh.PrivateKey {
+	t.Helper()
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	alice, bob, carol := mustGenerateKey(t), mustGenerateKey(t), mustGenerateKey(t)
+	want := strings.Repeat("This is only a test.  ", 5000)
+	enc, err := io.ReadAll(Encrypt(strings.NewReader(want), alice.PublicKey(), bob.PublicKey()))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	tests := []struct {
+		name     string
+		identity *ecdh.PrivateKey
+		want     string
+		wantErr  error
+	}{
+		{name: "Alice", identity: alice, want: want},
+		{name: "Bob", identity: bob, want: want},
+		{name: "Carol", identity: carol, wantErr: ErrNoMatchingKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(Decrypt(bytes.NewReader(enc), tt.identity))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decrypt() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Decrypt() returned %d bytes, want %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestDecryptTamperedHeader(t *testing.T) {
+	alice, bob := mustGenerateKey(t), mustGenerateKey(t)
+	enc, _ := io.ReadAll(Encrypt(strings.NewReader("This is only a test"), alice.PublicKey(), bob.PublicKey()))
+	// Corrupt Bob's stanza; Alice can still unwrap the key, but the header
+	// no longer authenticates.
+	enc[len(magic)+2+stanzaSize+1] ^= 0x01
+	if _, err := io.ReadAll(Decrypt(bytes.NewReader(enc), alice)); !errors.Is(err, ErrAuthentication) {
+		t.Errorf("Decrypt() error = %v, want %v", err, ErrAuthentication)
+	}
+}
+
+func TestKeysToAndFromPem(t *testing.T) {
+	priv := mustGenerateKey(t)
+	gotPriv, err := PrivateKeyFromPem(PrivateKeyToPem(priv))
+	if err != nil {
+		t.Fatalf("PrivateKeyFromPem() error = %v", err)
+	}
+	if !gotPriv.Equal(priv) {
+		t.Errorf("PrivateKeyFromPem() returned a different key")
+	}
+	gotPub, err := PublicKeyFromPem(PublicKeyToPem(priv.PublicKey()))
+	if err != nil {
+		t.Fatalf("PublicKeyFromPem() error = %v", err)
+	}
+	if !gotPub.Equal(priv.PublicKey()) {
+		t.Errorf("PublicKeyFromPem() returned a different key")
+	}
+	if _, err = PublicKeyFromPem(PrivateKeyToPem(priv)); err == nil {
+		t.Errorf("PublicKeyFromPem() accepted a private key")
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/bgallie/filters/x509
+
+go 1.24.2
+
+require github.com/bgallie/filters/pem v0.0.0-20261019162334-1ad9d528d24a
+
+require (
+	github.com/bgallie/filters/base64 v0.0.0-20250416201050-bb5407c2b958 // indirect
+	github.com/bgallie/filters/lines v0.0.0-20261019154801-6e313acf06b6 // indirect
+)
@@ -0,0 +1,169 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package x509 converts between PEM blocks read and written by the pem
+// package and the Go values of crypto/x509.  Well-known PEM types, such as
+// CERTIFICATE and PRIVATE KEY, are parsed into certificates and keys, and
+// certificates and keys are encoded into PEM blocks of the matching type.
+// Blocks of unknown types are returned as raw bytes.
+package x509
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"io"
+
+	"github.com/bgallie/filters/pem"
+)
+
+// The well-known PEM types.
+const (
+	TypeCertificate           = "CERTIFICATE"
+	TypeCertificateRequest    = "CERTIFICATE REQUEST"
+	TypeNewCertificateRequest = "NEW CERTIFICATE REQUEST"
+	TypeCRL                   = "X509 CRL"
+	TypePrivateKey            = "PRIVATE KEY"
+	TypeRSAPrivateKey         = "RSA PRIVATE KEY"
+	TypeECPrivateKey          = "EC PRIVATE KEY"
+	TypePublicKey             = "PUBLIC KEY"
+)
+
+// An Object is a parsed PEM block.
+type Object struct {
+	Block pem.Block // The type and headers of the block.
+	Value any       // The parsed value (see Parse).
+}
+
+// Parse parses der, the body of a PEM block of type typ, into a Go value:
+//
+//	CERTIFICATE                  *x509.Certificate
+//	CERTIFICATE REQUEST          *x509.CertificateRequest
+//	NEW CERTIFICATE REQUEST      *x509.CertificateRequest
+//	X509 CRL                     *x509.RevocationList
+//	PRIVATE KEY                  *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey or *ecdh.PrivateKey
+//	RSA PRIVATE KEY              *rsa.PrivateKey
+//	EC PRIVATE KEY               *ecdsa.PrivateKey
+//	PUBLIC KEY                   *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey or *ecdh.PublicKey
+//
+// Any other type is returned as der, unchanged.
+func Parse(typ string, der []byte) (any, error) {
+	var v any
+	var err error
+	switch typ {
+	case TypeCertificate:
+		v, err = x509.ParseCertificate(der)
+	case TypeCertificateRequest, TypeNewCertificateRequest:
+		v, err = x509.ParseCertificateRequest(der)
+	case TypeCRL:
+		v, err = x509.ParseRevocationList(der)
+	case TypePrivateKey:
+		v, err = x509.ParsePKCS8PrivateKey(der)
+	case TypeRSAPrivateKey:
+		v, err = x509.ParsePKCS1PrivateKey(der)
+	case TypeECPrivateKey:
+		v, err = x509.ParseECPrivateKey(der)
+	case TypePublicKey:
+		v, err = x509.ParsePKIXPublicKey(der)
+	default:
+		return der, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("x509: error parsing a %q block: %w", typ, err)
+	}
+	return v, nil
+}
+
+// Decode reads a single PEM block from r and parses it (see Parse).  The
+// options are passed to pem.FromPem.
+func Decode(r io.Reader, opts ...pem.Option) (any, pem.Block, error) {
+	rdr, blk := pem.FromPem(r, opts...)
+	der, err := io.ReadAll(rdr)
+	if err != nil {
+		return nil, blk, fmt.Errorf("x509: error reading a PEM block: %w", err)
+	}
+	v, err := Parse(blk.Type, der)
+	return v, blk, err
+}
+
+// DecodeAll reads every PEM block in a bundle from r and parses each of
+// them (see Parse).  The options are passed to pem.NewReader.
+func DecodeAll(r io.Reader, opts ...pem.Option) ([]Object, error) {
+	var objs []Object
+	rdr := pem.NewReader(r, opts...)
+	for entry := range rdr.All() {
+		der, err := io.ReadAll(entry.Body)
+		if err != nil {
+			return objs, fmt.Errorf("x509: error reading the %q block on line %d: %w", entry.Block.Type, entry.Line, err)
+		}
+		v, err := Parse(entry.Block.Type, der)
+		if err != nil {
+			return objs, fmt.Errorf("x509: line %d: %w", entry.Line, err)
+		}
+		objs = append(objs, Object{Block: entry.Block, Value: v})
+	}
+	return objs, rdr.Err()
+}
+
+// Marshal returns the PEM type and the DER encoding of v, which must be one
+// of the values returned by Parse other than raw bytes.  Private keys are
+// marshaled in the traditional form for RSA and ECDSA keys (RSA PRIVATE KEY
+// and EC PRIVATE KEY) and in PKCS #8 form for other keys.
+func Marshal(v any) (string, []byte, error) {
+	switch v := v.(type) {
+	case *x509.Certificate:
+		return TypeCertificate, v.Raw, nil
+	case *x509.CertificateRequest:
+		return TypeCertificateRequest, v.Raw, nil
+	case *x509.RevocationList:
+		return TypeCRL, v.Raw, nil
+	case *rsa.PrivateKey:
+		return TypeRSAPrivateKey, x509.MarshalPKCS1PrivateKey(v), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(v)
+		if err != nil {
+			return "", nil, fmt.Errorf("x509: %w", err)
+		}
+		return TypeECPrivateKey, der, nil
+	case ed25519.PrivateKey, *ecdh.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(v)
+		if err != nil {
+			return "", nil, fmt.Errorf("x509: %w", err)
+		}
+		return TypePrivateKey, der, nil
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey, *ecdh.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(v)
+		if err != nil {
+			return "", nil, fmt.Errorf("x509: %w", err)
+		}
+		return TypePublicKey, der, nil
+	case []byte:
+		return "", nil, fmt.Errorf("x509: raw bytes have no PEM type; use pem.ToPem")
+	}
+	return "", nil, fmt.Errorf("x509: unsupported type %T", v)
+}
+
+// Encode encodes v as a PEM block of the matching type (see Marshal).  The
+// options are passed to pem.ToPem.  The PEM encoded data can be read using
+// the returned PipeReader.
+func Encode(v any, opts ...pem.Option) *io.PipeReader {
+	return EncodeBlock(v, pem.Block{}, opts...)
+}
+
+// EncodeBlock is like Encode, but writes the headers of blk as well.  The
+// type of blk is replaced by the type matching v.
+func EncodeBlock(v any, blk pem.Block, opts ...pem.Option) *io.PipeReader {
+	typ, der, err := Marshal(v)
+	if err != nil {
+		rRdr, rWrtr := io.Pipe()
+		rWrtr.CloseWithError(err)
+		return rRdr
+	}
+	blk.Type = typ
+	return pem.ToPem(bytes.NewReader(der), blk, opts...)
+}
@@ -0,0 +1,106 @@
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bgallie/filters/pem"
+)
+
+func newCertificate(t *testing.T, key crypto.Signer) *x509.Certificate {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() error = %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return cert
+}
+
+func TestEncodeDecode(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	tests := []struct {
+		name     string
+		v        any
+		wantType string
+	}{
+		{name: "Certificate", v: newCertificate(t, ecKey), wantType: TypeCertificate},
+		{name: "ECPrivateKey", v: ecKey, wantType: TypeECPrivateKey},
+		{name: "RSAPrivateKey", v: rsaKey, wantType: TypeRSAPrivateKey},
+		{name: "PKCS8PrivateKey", v: edKey, wantType: TypePrivateKey},
+		{name: "PublicKey", v: &ecKey.PublicKey, wantType: TypePublicKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, blk, err := Decode(Encode(tt.v))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if blk.Type != tt.wantType {
+				t.Errorf("Decode() type = %q, want %q", blk.Type, tt.wantType)
+			}
+			if eq, ok := got.(interface{ Equal(crypto.PublicKey) bool }); ok && tt.wantType == TypePublicKey {
+				if !eq.Equal(tt.v) {
+					t.Errorf("Decode() = %v, want %v", got, tt.v)
+				}
+			} else if eq, ok := got.(interface{ Equal(crypto.PrivateKey) bool }); ok {
+				if !eq.Equal(tt.v) {
+					t.Errorf("Decode() = %v, want %v", got, tt.v)
+				}
+			} else if !reflect.DeepEqual(got, tt.v) {
+				t.Errorf("Decode() = %v, want %v", got, tt.v)
+			}
+		})
+	}
+}
+
+func TestDecodeAll(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	var bundle bytes.Buffer
+	io.Copy(&bundle, Encode(newCertificate(t, ecKey)))
+	io.Copy(&bundle, pem.ToPem(strings.NewReader("This is only a test"), pem.Block{Type: "UNKNOWN"}))
+	io.Copy(&bundle, Encode(ecKey))
+	objs, err := DecodeAll(&bundle)
+	if err != nil {
+		t.Fatalf("DecodeAll() error = %v", err)
+	}
+	if len(objs) != 3 {
+		t.Fatalf("DecodeAll() returned %d objects, want 3", len(objs))
+	}
+	if _, ok := objs[0].Value.(*x509.Certificate); !ok {
+		t.Errorf("DecodeAll()[0] = %T, want *x509.Certificate", objs[0].Value)
+	}
+	if raw, ok := objs[1].Value.([]byte); !ok || string(raw) != "This is only a test" {
+		t.Errorf("DecodeAll()[1] = %v, want the raw bytes", objs[1].Value)
+	}
+	if key, ok := objs[2].Value.(*ecdsa.PrivateKey); !ok || !key.Equal(ecKey) {
+		t.Errorf("DecodeAll()[2] = %T, want the *ecdsa.PrivateKey", objs[2].Value)
+	}
+}
+
+func TestEncodeUnsupported(t *testing.T) {
+	if _, err := io.ReadAll(Encode([]byte("raw"))); err == nil {
+		t.Errorf("Encode() of raw bytes did not fail")
+	}
+}
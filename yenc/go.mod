@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/yenc
+
+go 1.24.2
@@ -0,0 +1,342 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package yenc defines filters to encode/decode data using yEnc, the 8-bit
+// encoding used to post binaries to Usenet.  The encoded data is framed by a
+// "=ybegin" line, a "=ypart" line for multi-part posts, and a "=yend" line
+// holding the size and CRC32 of the data.  These filters can be connected to
+// other filters via io.Pipes.
+package yenc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultLineLength is the line length used when the Header does not give
+// one.
+const DefaultLineLength = 128
+
+var (
+	// ErrSize is returned when the size in the "=yend" line, or the size in
+	// the Header given to ToYEnc, does not match the data.
+	ErrSize = errors.New("yenc: size mismatch")
+	// ErrChecksum is returned when the CRC32 in the "=yend" line does not
+	// match the data.
+	ErrChecksum = errors.New("yenc: CRC32 mismatch")
+	// ErrFormat is returned when the "=ybegin", "=ypart" or "=yend" line is
+	// missing or malformed.
+	ErrFormat = errors.New("yenc: malformed yEnc data")
+)
+
+// A Header holds the values of the "=ybegin" and "=ypart" lines.
+type Header struct {
+	Name  string // The name of the file.
+	Line  int    // The length of the encoded lines.
+	Size  int64  // The size of the whole file.
+	Part  int    // The part number of a multi-part post, or 0.
+	Total int    // The number of parts of a multi-part post, if known.
+	Begin int64  // The offset, from 1, of the first byte of the part.
+	End   int64  // The offset of the last byte of the part.
+}
+
+// size returns the number of bytes of data covered by h.
+func (h Header) size() int64 {
+	if h.Part > 0 {
+		return h.End - h.Begin + 1
+	}
+	return h.Size
+}
+
+// encoder encodes data written to it in yEnc.  The last byte written is
+// held back until the next is written, or the encoder is closed, so that a
+// space or tab at the end of the data can be escaped.
+type encoder struct {
+	w       *bufio.Writer
+	line    int
+	col     int
+	pending int // The held back byte, or -1.
+	n       int64
+	crc     uint32
+}
+
+func (e *encoder) encode(c byte, last bool) error {
+	o := c + 42
+	escape := false
+	switch o {
+	case 0, '\n', '\r', '=':
+		escape = true
+	case ' ', '\t':
+		escape = e.col == 0 || e.col >= e.line-1 || last
+	case '.':
+		escape = e.col == 0
+	}
+	if escape {
+		e.w.WriteByte('=')
+		o += 64
+		e.col++
+	}
+	e.w.WriteByte(o)
+	if e.col++; e.col >= e.line {
+		e.col = 0
+		return e.w.WriteByte('\n')
+	}
+	return nil
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	e.crc = crc32.Update(e.crc, crc32.IEEETable, p)
+	e.n += int64(len(p))
+	for i, c := range p {
+		if e.pending >= 0 {
+			if err := e.encode(byte(e.pending), false); err != nil {
+				return i, err
+			}
+		}
+		e.pending = int(c)
+	}
+	return len(p), nil
+}
+
+// Close encodes the held back byte and ends the last line.
+func (e *encoder) Close() error {
+	if e.pending >= 0 {
+		e.encode(byte(e.pending), true)
+		e.pending = -1
+	}
+	if e.col > 0 {
+		e.col = 0
+		return e.w.WriteByte('\n')
+	}
+	return nil
+}
+
+// ToYEnc reads data from r, encodes it using yEnc, framed by the lines
+// described by hdr.  Since the "=ybegin" line comes first, hdr.Size, and,
+// for a part of a multi-part post, hdr.Begin and hdr.End, must be given; the
+// data is checked against them.  The yEnc encoded data can be read using the
+// returned PipeReader.
+func ToYEnc(r io.Reader, hdr Header) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	if hdr.Line <= 0 {
+		hdr.Line = DefaultLineLength
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		bw := bufio.NewWriter(rWrtr)
+		if hdr.Part > 0 {
+			fmt.Fprintf(bw, "=ybegin part=%d", hdr.Part)
+			if hdr.Total > 0 {
+				fmt.Fprintf(bw, " total=%d", hdr.Total)
+			}
+			fmt.Fprintf(bw, " line=%d size=%d name=%s\n", hdr.Line, hdr.Size, hdr.Name)
+			fmt.Fprintf(bw, "=ypart begin=%d end=%d\n", hdr.Begin, hdr.End)
+		} else {
+			fmt.Fprintf(bw, "=ybegin line=%d size=%d name=%s\n", hdr.Line, hdr.Size, hdr.Name)
+		}
+		enc := &encoder{w: bw, line: hdr.Line, pending: -1}
+		_, err := io.Copy(enc, r)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an io.Reader to a yEnc encoder: %w", err))
+			return
+		}
+		enc.Close()
+		if enc.n != hdr.size() {
+			bw.Flush()
+			rWrtr.CloseWithError(fmt.Errorf("%w: the header gives %d bytes, but the data is %d bytes", ErrSize, hdr.size(), enc.n))
+			return
+		}
+		if hdr.Part > 0 {
+			fmt.Fprintf(bw, "=yend size=%d part=%d pcrc32=%08x\n", enc.n, hdr.Part, enc.crc)
+		} else {
+			fmt.Fprintf(bw, "=yend size=%d crc32=%08x\n", enc.n, enc.crc)
+		}
+		if err = bw.Flush(); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+// keywords splits a yEnc control line into its keyword values.  The name
+// keyword is always last, and its value runs to the end of the line.
+func keywords(line string) map[string]string {
+	kw := make(map[string]string)
+	if i := strings.Index(line, " name="); i >= 0 {
+		kw["name"] = line[i+len(" name="):]
+		line = line[:i]
+	}
+	for _, f := range strings.Fields(line)[1:] {
+		if k, v, ok := strings.Cut(f, "="); ok {
+			kw[k] = v
+		}
+	}
+	return kw
+}
+
+// parseInt parses the value of keyword k, which must be present if required
+// is set.
+func parseInt(kw map[string]string, k string, required bool) (int64, error) {
+	v, ok := kw[k]
+	if !ok {
+		if required {
+			return 0, fmt.Errorf("%w: missing %q keyword", ErrFormat, k)
+		}
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad %q keyword: %q", ErrFormat, k, v)
+	}
+	return n, nil
+}
+
+// readLine returns the next line from br without its line ending.
+func readLine(br *bufio.Reader) (string, error) {
+	line, err := br.ReadString('\n')
+	if errors.Is(err, io.EOF) && len(line) > 0 {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// readHeader skips any lines before the "=ybegin" line, and parses it and
+// any "=ypart" line.
+func readHeader(br *bufio.Reader) (Header, error) {
+	var hdr Header
+	var line string
+	var err error
+	for !strings.HasPrefix(line, "=ybegin ") {
+		if line, err = readLine(br); err != nil {
+			return hdr, fmt.Errorf("%w: missing \"=ybegin\" line: %w", ErrFormat, err)
+		}
+	}
+	kw := keywords(line)
+	hdr.Name = kw["name"]
+	var n int64
+	if n, err = parseInt(kw, "line", true); err != nil {
+		return hdr, err
+	}
+	hdr.Line = int(n)
+	if hdr.Size, err = parseInt(kw, "size", true); err != nil {
+		return hdr, err
+	}
+	if n, err = parseInt(kw, "part", false); err != nil {
+		return hdr, err
+	}
+	hdr.Part = int(n)
+	if n, err = parseInt(kw, "total", false); err != nil {
+		return hdr, err
+	}
+	hdr.Total = int(n)
+	if hdr.Part == 0 {
+		return hdr, nil
+	}
+	if line, err = readLine(br); err != nil || !strings.HasPrefix(line, "=ypart ") {
+		return hdr, fmt.Errorf("%w: missing \"=ypart\" line", ErrFormat)
+	}
+	kw = keywords(line)
+	if hdr.Begin, err = parseInt(kw, "begin", true); err != nil {
+		return hdr, err
+	}
+	if hdr.End, err = parseInt(kw, "end", true); err != nil {
+		return hdr, err
+	}
+	return hdr, nil
+}
+
+// checkTrailer checks the "=yend" line against the size and CRC32 of the
+// decoded data.
+func checkTrailer(line string, hdr Header, n int64, crc uint32) error {
+	kw := keywords(line)
+	size, err := parseInt(kw, "size", true)
+	if err != nil {
+		return err
+	}
+	if size != n || n != hdr.size() {
+		return fmt.Errorf("%w: the header gives %d bytes and the trailer %d, but the data is %d bytes", ErrSize, hdr.size(), size, n)
+	}
+	key := "crc32"
+	if hdr.Part > 0 {
+		key = "pcrc32"
+	}
+	if v, ok := kw[key]; ok {
+		want, err := strconv.ParseUint(v, 16, 32)
+		if err != nil {
+			return fmt.Errorf("%w: bad %q keyword: %q", ErrFormat, key, v)
+		}
+		if uint32(want) != crc {
+			return fmt.Errorf("%w: the trailer gives %08x, but the data has %08x", ErrChecksum, want, crc)
+		}
+	}
+	return nil
+}
+
+// FromYEnc reads yEnc encoded data from r, decodes it.  Any lines before
+// the "=ybegin" line are skipped.  The size, and the CRC32 if the "=yend"
+// line gives one, are checked when the "=yend" line is read.  The decoded
+// data can be read using the returned PipeReader, and the values of the
+// "=ybegin" and "=ypart" lines are returned in a Header.
+func FromYEnc(r io.Reader) (*io.PipeReader, Header) {
+	rRdr, rWrtr := io.Pipe()
+	br := bufio.NewReader(r)
+	hdr, err := readHeader(br)
+	if err != nil {
+		rWrtr.CloseWithError(err)
+		return rRdr, hdr
+	}
+
+	go func() {
+		defer rWrtr.Close()
+		var (
+			n   int64
+			crc uint32
+			out []byte
+		)
+		for {
+			line, err := readLine(br)
+			if errors.Is(err, io.EOF) {
+				rWrtr.CloseWithError(fmt.Errorf("%w: missing \"=yend\" line", ErrFormat))
+				return
+			} else if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+			if strings.HasPrefix(line, "=yend") {
+				if err = checkTrailer(line, hdr, n, crc); err != nil {
+					rWrtr.CloseWithError(err)
+				}
+				return
+			}
+			// Undo NNTP dot stuffing.
+			if strings.HasPrefix(line, "..") {
+				line = line[1:]
+			}
+			out = out[:0]
+			for i := 0; i < len(line); i++ {
+				c := line[i]
+				if c == '=' && i+1 < len(line) {
+					i++
+					c = line[i] - 64
+				}
+				out = append(out, c-42)
+			}
+			crc = crc32.Update(crc, crc32.IEEETable, out)
+			n += int64(len(out))
+			if _, err = rWrtr.Write(out); err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+				return
+			}
+		}
+	}()
+
+	return rRdr, hdr
+}
@@ -0,0 +1,133 @@
+package yenc
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testData encodes to each of the critical characters, and a space at the
+// start and at the end of a line.
+const (
+	testData    = "\xd6\xe0\xe3\x13\xf6\x04\x0a\x41\x42\xf6"
+	testEncoded = "=ybegin line=4 size=10 name=test file.bin\n" +
+		"=@=J\n=M=}\n=`.4\nkl=`\n" +
+		"=yend size=10 crc32=6ee593bb\n"
+)
+
+func TestToYEnc(t *testing.T) {
+	type args struct {
+		r   io.Reader
+		hdr Header
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(testData), hdr: Header{Name: "test file.bin", Line: 4, Size: 10}},
+			want: testEncoded,
+		},
+		{
+			name: "Part",
+			args: args{r: strings.NewReader("yEnc"), hdr: Header{Name: "test.txt", Size: 8, Part: 2, Total: 2, Begin: 5, End: 8}},
+			want: "=ybegin part=2 total=2 line=128 size=8 name=test.txt\n" +
+				"=ypart begin=5 end=8\n" +
+				"\xa3o\x98\x8d\n" +
+				"=yend size=4 part=2 pcrc32=6ee709ea\n",
+		},
+		{
+			name:    "WrongSize",
+			args:    args{r: strings.NewReader(testData), hdr: Header{Name: "test.bin", Size: 9}},
+			want:    "=ybegin line=128 size=9 name=test.bin\n=@=J=M=} .4kl=`\n",
+			wantErr: ErrSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(ToYEnc(tt.args.r, tt.args.hdr))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToYEnc() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToYEnc() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromYEnc(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		want1   Header
+		wantErr error
+	}{
+		{
+			name:  "TestOne",
+			args:  args{r: strings.NewReader("Subject: a test\r\n\r\n" + strings.ReplaceAll(testEncoded, "\n", "\r\n"))},
+			want:  testData,
+			want1: Header{Name: "test file.bin", Line: 4, Size: 10},
+		},
+		{
+			name: "Part",
+			args: args{r: strings.NewReader("=ybegin part=2 total=2 line=128 size=8 name=test.txt\n" +
+				"=ypart begin=5 end=8\n" +
+				"\xa3o\x98\x8d\n" +
+				"=yend size=4 part=2 pcrc32=6ee709ea\n")},
+			want:  "yEnc",
+			want1: Header{Name: "test.txt", Line: 128, Size: 8, Part: 2, Total: 2, Begin: 5, End: 8},
+		},
+		{
+			name:    "BadCRC",
+			args:    args{r: strings.NewReader(strings.Replace(testEncoded, "6ee593bb", "6ee593bc", 1))},
+			want:    testData,
+			want1:   Header{Name: "test file.bin", Line: 4, Size: 10},
+			wantErr: ErrChecksum,
+		},
+		{
+			name:    "BadSize",
+			args:    args{r: strings.NewReader(strings.Replace(testEncoded, "kl=`\n", "kl\n", 1))},
+			want:    testData[:9],
+			want1:   Header{Name: "test file.bin", Line: 4, Size: 10},
+			wantErr: ErrSize,
+		},
+		{
+			name:    "MissingEnd",
+			args:    args{r: strings.NewReader(strings.Replace(testEncoded, "=yend size=10 crc32=6ee593bb\n", "", 1))},
+			want:    testData,
+			want1:   Header{Name: "test file.bin", Line: 4, Size: 10},
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "MissingBegin",
+			args:    args{r: strings.NewReader("This is not yEnc.\n")},
+			want:    "",
+			wantErr: ErrFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, got1 := FromYEnc(tt.args.r)
+			got, err := io.ReadAll(rdr)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromYEnc() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromYEnc() got = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("FromYEnc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
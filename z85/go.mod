@@ -0,0 +1,3 @@
+module github.com/bgallie/filters/z85
+
+go 1.24.2
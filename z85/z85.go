@@ -0,0 +1,234 @@
+// Copyright 2026 Billy G. Allie.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package z85 defines filters to encode/decode data using Z85 encoding, the
+// ZeroMQ variant of ASCII85 described in ZeroMQ RFC 32.  Its alphabet is safe
+// to use in source code, and it encodes 4 bytes as 5 characters.
+//
+// Z85 requires the data to be a multiple of 4 bytes long.  WithPadding lifts
+// that restriction by padding the data with zero bytes and appending one
+// character, '0' to '3', giving the number of padding bytes to remove when
+// decoding.  These filters can be connected to other filters via io.Pipes.
+package z85
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#"
+
+var decodeMap [256]byte
+
+func init() {
+	for i := range decodeMap {
+		decodeMap[i] = 0xff
+	}
+	for i := 0; i < len(alphabet); i++ {
+		decodeMap[alphabet[i]] = byte(i)
+	}
+}
+
+// ErrLength is returned when the data, or the encoded data, is not
+// correctly aligned: the data is not a multiple of 4 bytes long, or the
+// encoded data does not end on a 5 character boundary.
+var ErrLength = errors.New("z85: misaligned data")
+
+// CorruptInputError is returned when the encoded data holds an invalid
+// character, or a group that does not fit in 4 bytes.  Its value is the
+// offset of the character, or of the start of the group.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "z85: illegal data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// An Option configures the behaviour of ToZ85 and FromZ85.
+type Option func(*options)
+
+type options struct {
+	padding bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithPadding allows data of any length to be encoded, by padding it to a
+// multiple of 4 bytes and appending the padding count, and expects the
+// padding count when decoding.
+func WithPadding() Option {
+	return func(o *options) {
+		o.padding = true
+	}
+}
+
+// encoder encodes data written to it in Z85.
+type encoder struct {
+	w     io.Writer
+	group [4]byte
+	nbuf  int
+	out   [5]byte
+}
+
+func (e *encoder) flushGroup() error {
+	v := uint32(e.group[0])<<24 | uint32(e.group[1])<<16 | uint32(e.group[2])<<8 | uint32(e.group[3])
+	for i := 4; i >= 0; i-- {
+		e.out[i] = alphabet[v%85]
+		v /= 85
+	}
+	e.nbuf = 0
+	_, err := e.w.Write(e.out[:])
+	return err
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	for i, c := range p {
+		e.group[e.nbuf] = c
+		if e.nbuf++; e.nbuf == len(e.group) {
+			if err := e.flushGroup(); err != nil {
+				return i, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// ToZ85 reads data from r, encodes it using Z85.  Unless WithPadding is
+// given, the data must be a multiple of 4 bytes long.  The Z85 encoded data
+// can be read using the returned PipeReader.
+func ToZ85(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		bw := bufio.NewWriter(rWrtr)
+		enc := &encoder{w: bw}
+		n, err := io.Copy(enc, r)
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error copying (io.Copy) from an io.Reader to a Z85 encoder: %w", err))
+			return
+		}
+		if o.padding {
+			pad := (4 - enc.nbuf) % 4
+			if pad > 0 {
+				clear(enc.group[enc.nbuf:])
+				err = enc.flushGroup()
+			}
+			if err == nil {
+				err = bw.WriteByte(byte('0' + pad))
+			}
+		} else if enc.nbuf != 0 {
+			bw.Flush()
+			rWrtr.CloseWithError(fmt.Errorf("%w: the data is %d bytes long, which is not a multiple of 4", ErrLength, n))
+			return
+		}
+		if err == nil {
+			err = bw.Flush()
+		}
+		if err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n' || b == '\v' || b == '\f'
+}
+
+// FromZ85 reads Z85 encoded data from r, decodes it.  White space in the
+// encoded data is ignored.  With WithPadding, the last decoded group is
+// withheld until the padding count has been read.  The decoded data can be
+// read using the returned PipeReader.
+func FromZ85(r io.Reader, opts ...Option) *io.PipeReader {
+	rRdr, rWrtr := io.Pipe()
+	o := newOptions(opts)
+
+	go func() {
+		defer rWrtr.Close()
+		var (
+			buf     = make([]byte, 4096)
+			out     = make([]byte, 0, len(buf))
+			v       uint64
+			ndigits int
+			start   int64 // The offset of the current group.
+			offset  int64
+			held    []byte // The last decoded group, when padding.
+			last    byte   // The last character read.
+		)
+		for {
+			n, err := r.Read(buf)
+			out = out[:0]
+			for _, c := range buf[:n] {
+				offset++
+				if isSpace(c) {
+					continue
+				}
+				d := decodeMap[c]
+				if d == 0xff {
+					rWrtr.Write(out)
+					rWrtr.CloseWithError(CorruptInputError(offset - 1))
+					return
+				}
+				if ndigits == 0 {
+					start = offset - 1
+				}
+				last = c
+				v = v*85 + uint64(d)
+				if ndigits++; ndigits < 5 {
+					continue
+				}
+				if v > 0xffffffff {
+					rWrtr.Write(out)
+					rWrtr.CloseWithError(CorruptInputError(start))
+					return
+				}
+				group := []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+				v, ndigits = 0, 0
+				if o.padding {
+					out = append(out, held...)
+					held = group
+				} else {
+					out = append(out, group...)
+				}
+			}
+			if _, werr := rWrtr.Write(out); werr != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", werr))
+				return
+			}
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				rWrtr.CloseWithError(fmt.Errorf("error reading from an io.Reader: %w", err))
+				return
+			}
+		}
+		if !o.padding {
+			if ndigits != 0 {
+				rWrtr.CloseWithError(fmt.Errorf("%w: the encoded data ends with a partial group of %d characters", ErrLength, ndigits))
+			}
+			return
+		}
+		pad := int(last - '0')
+		if ndigits != 1 || pad < 0 || pad > 3 || (pad > 0 && held == nil) {
+			rWrtr.CloseWithError(fmt.Errorf("%w: the encoded data does not end with a padding count", ErrLength))
+			return
+		}
+		if _, err := rWrtr.Write(held[:len(held)-pad]); err != nil {
+			rWrtr.CloseWithError(fmt.Errorf("error writing to an io.PipeWriter: %w", err))
+		}
+	}()
+
+	return rRdr
+}
@@ -0,0 +1,126 @@
+package z85
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// helloWorld is the test vector from ZeroMQ RFC 32.
+const helloWorld = "\x86\x4f\xd2\x6f\xb5\x59\xf7\x5b"
+
+func TestToZ85(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader(helloWorld)},
+			want: "HelloWorld",
+		},
+		{
+			name:    "Misaligned",
+			args:    args{r: strings.NewReader(helloWorld + "!")},
+			want:    "HelloWorld",
+			wantErr: ErrLength,
+		},
+		{
+			name: "Padding",
+			args: args{r: strings.NewReader(helloWorld + "!"), opts: []Option{WithPadding()}},
+			want: "HelloWorldaPIGx3",
+		},
+		{
+			name: "PaddingAligned",
+			args: args{r: strings.NewReader(helloWorld), opts: []Option{WithPadding()}},
+			want: "HelloWorld0",
+		},
+		{
+			name: "PaddingEmpty",
+			args: args{r: strings.NewReader(""), opts: []Option{WithPadding()}},
+			want: "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(ToZ85(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToZ85() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToZ85() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromZ85(t *testing.T) {
+	type args struct {
+		r    io.Reader
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "TestOne",
+			args: args{r: strings.NewReader("HelloWorld\n")},
+			want: helloWorld,
+		},
+		{
+			name:    "Misaligned",
+			args:    args{r: strings.NewReader("HelloWorl")},
+			want:    "\x86\x4f\xd2\x6f",
+			wantErr: ErrLength,
+		},
+		{
+			name:    "InvalidCharacter",
+			args:    args{r: strings.NewReader("Hello,World")},
+			want:    "\x86\x4f\xd2\x6f",
+			wantErr: CorruptInputError(5),
+		},
+		{
+			name:    "GroupOverflow",
+			args:    args{r: strings.NewReader("HelloWorld#####")},
+			want:    helloWorld,
+			wantErr: CorruptInputError(10),
+		},
+		{
+			name: "Padding",
+			args: args{r: strings.NewReader("HelloWorldaPIGx3"), opts: []Option{WithPadding()}},
+			want: helloWorld + "!",
+		},
+		{
+			name: "PaddingAligned",
+			args: args{r: strings.NewReader("HelloWorld0"), opts: []Option{WithPadding()}},
+			want: helloWorld,
+		},
+		{
+			name:    "PaddingMissing",
+			args:    args{r: strings.NewReader("HelloWorld"), opts: []Option{WithPadding()}},
+			want:    "\x86\x4f\xd2\x6f",
+			wantErr: ErrLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(FromZ85(tt.args.r, tt.args.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromZ85() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FromZ85() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}